@@ -0,0 +1,93 @@
+package shiftpad
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Attendance records whether an approved taker showed up. It is set by editors after the shift is over, see Auth.CanSetAttendance.
+type Attendance int
+
+const (
+	AttendanceUnknown Attendance = iota
+	Attended
+	NoShow
+	Excused // did not show up, but for an accepted reason
+)
+
+// Attendances are the attendance states which can be set.
+var Attendances = []Attendance{Attended, NoShow, Excused}
+
+func ParseAttendance(s string) Attendance {
+	for _, attendance := range Attendances {
+		if attendance.String() == s {
+			return attendance
+		}
+	}
+	return AttendanceUnknown
+}
+
+func (attendance Attendance) String() string {
+	switch attendance {
+	case Attended:
+		return "attended"
+	case NoShow:
+		return "no-show"
+	case Excused:
+		return "excused"
+	default:
+		return ""
+	}
+}
+
+// Reliability counts the attendance of the approved takes of a taker name in shifts which are over.
+type Reliability struct {
+	Name     string
+	Attended int
+	NoShow   int
+	Excused  int
+	Unknown  int
+}
+
+// Percent returns the share of attended takes among attended and no-show takes, or -1 if there are none.
+func (reliability Reliability) Percent() float64 {
+	if reliability.Attended+reliability.NoShow == 0 {
+		return -1
+	}
+	return 100 * float64(reliability.Attended) / float64(reliability.Attended+reliability.NoShow)
+}
+
+// SummarizeAttendance returns the reliability of each taker name in the given shifts, sorted by name.
+// Only approved takes in shifts which are over and whose name is accepted by the shiftname func are considered.
+func SummarizeAttendance(shifts []Shift, shiftname func(string) bool) []Reliability {
+	var result []Reliability
+	for _, shift := range shifts {
+		if !shift.Over() || !shiftname(shift.Name) {
+			continue
+		}
+		for _, take := range shift.Takes {
+			if !take.Approved || take.Name == "" {
+				continue
+			}
+			i := slices.IndexFunc(result, func(r Reliability) bool { return r.Name == take.Name })
+			if i < 0 {
+				result = append(result, Reliability{Name: take.Name})
+				i = len(result) - 1
+			}
+			switch take.Attendance {
+			case Attended:
+				result[i].Attended++
+			case NoShow:
+				result[i].NoShow++
+			case Excused:
+				result[i].Excused++
+			default:
+				result[i].Unknown++
+			}
+		}
+	}
+	slices.SortFunc(result, func(a, b Reliability) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return result
+}
@@ -0,0 +1,38 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeAttendance(t *testing.T) {
+	past := time.Now().Add(-48 * time.Hour)
+	shifts := []Shift{
+		{Name: "a", Begin: past, End: past.Add(time.Hour), Takes: []Take{{Name: "alice", Approved: true, Attendance: Attended}, {Name: "bob", Approved: true, Attendance: NoShow}}},
+		{Name: "a", Begin: past, End: past.Add(time.Hour), Takes: []Take{{Name: "alice", Approved: true, Attendance: NoShow}, {Name: "bob", Approved: true, Attendance: Excused}, {Name: "carol"}}},
+		{Name: "a", Begin: past, End: past.Add(time.Hour), Takes: []Take{{Name: "alice", Approved: true}}},
+		{Name: "b", Begin: past, End: past.Add(time.Hour), Takes: []Take{{Name: "alice", Approved: true, Attendance: NoShow}}},             // other shift name
+		{Name: "a", Begin: time.Now(), End: time.Now().Add(time.Hour), Takes: []Take{{Name: "alice", Approved: true, Attendance: NoShow}}}, // not over
+	}
+	got := SummarizeAttendance(shifts, func(name string) bool { return name == "a" })
+	if len(got) != 2 {
+		t.Fatalf("got %d takers, want 2: %+v", len(got), got)
+	}
+	if alice := got[0]; alice != (Reliability{Name: "alice", Attended: 1, NoShow: 1, Unknown: 1}) || alice.Percent() != 50 {
+		t.Fatalf("got %+v", alice)
+	}
+	if bob := got[1]; bob.Percent() != 0 || bob.Excused != 1 {
+		t.Fatalf("got %+v", bob)
+	}
+	if (Reliability{Excused: 1}).Percent() != -1 {
+		t.Fatal("want -1 without attended and no-show takes")
+	}
+
+	auth := Auth{PayoutAll: true}
+	if auth.CanPayoutTake(shifts[0], shifts[0].Takes[1]) || !auth.CanPayoutTake(shifts[1], shifts[1].Takes[1]) {
+		t.Fatal("no-show takes must not be paid out, excused takes may")
+	}
+	if ParseAttendance(NoShow.String()) != NoShow || ParseAttendance("") != AttendanceUnknown {
+		t.Fatal("ParseAttendance")
+	}
+}
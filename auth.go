@@ -28,12 +28,16 @@ type Auth struct {
 	Admin            bool
 	Apply            []string
 	ApplyAll         bool
+	Cancel           bool   // cancel takes of Auth.TakerName
+	CancelDeadline   string // cronexpr or duration before shift begin
 	Edit             []string
 	EditAll          bool
 	EditRetroAlways  bool
 	Expires          string // yyyy-mm-dd
 	Note             string
+	Payout           []string
 	PayoutAll        bool
+	Record           bool // record worked times of takes of Auth.TakerName
 	Take             []string
 	TakeAll          bool
 	TakeDeadline     string   // apply and take, cronexpr
@@ -56,9 +60,11 @@ func DecodeAuth(s string) (Auth, error) {
 	if values.Get("admin") != "" {
 		auth.Admin = true
 		auth.ApplyAll = true
+		auth.Cancel = true
 		auth.EditAll = true
 		auth.EditRetroAlways = true
 		auth.PayoutAll = true
+		auth.Record = true
 		auth.TakeAll = true
 		auth.TakerNameAll = true
 		auth.ViewTakerContact = true
@@ -69,6 +75,12 @@ func DecodeAuth(s string) (Auth, error) {
 		} else {
 			auth.Apply = values["apply"]
 		}
+		if values.Get("cancel") != "" {
+			auth.Cancel = true
+		}
+		if dl := values.Get("cancel-deadline"); dl != "" {
+			auth.CancelDeadline = dl
+		}
 		if values.Get("edit-all") != "" {
 			auth.ApplyAll = true
 			auth.EditAll = true
@@ -84,6 +96,11 @@ func DecodeAuth(s string) (Auth, error) {
 		}
 		if values.Get("payout-all") != "" {
 			auth.PayoutAll = true
+		} else {
+			auth.Payout = values["payout"]
+		}
+		if values.Get("record") != "" {
+			auth.Record = true
 		}
 		if values.Get("take-all") != "" {
 			auth.TakeAll = true
@@ -137,6 +154,16 @@ func (auth Auth) Active() bool {
 	return time.Now().Before(exp)
 }
 
+// CanAcceptOffer returns true if auth could take the shift if it was not fully taken (see CanTakeShift), and the take is offered for handover.
+// The taker name must be checked separately.
+func (auth Auth) CanAcceptOffer(shift Shift, take Take) bool {
+	return take.Offered && !take.PaidOut && auth.CanTake(shift.Name) && !shift.Over() && shift.AfterDeadline(auth.TakeDeadline, time.Now())
+}
+
+func (auth Auth) CanAcceptOfferName(shift Shift, take Take, name string) bool {
+	return auth.CanAcceptOffer(shift, take) && name != take.Name && (auth.TakerNameAll || slices.Contains(auth.TakerName, name))
+}
+
 func (auth Auth) CanApply(shiftname string) bool {
 	return auth.ApplyAll || containsFold(auth.Apply, shiftname)
 }
@@ -149,6 +176,11 @@ func (auth Auth) CanApplyName(shift Shift, name string) bool {
 	return auth.CanApplyShift(shift) && (auth.TakerNameAll || slices.Contains(auth.TakerName, name))
 }
 
+// CanCancelTake returns true if the take belongs to one of auth.TakerName, has not been paid out, and the shift begins after the cancel deadline.
+func (auth Auth) CanCancelTake(shift Shift, take Take) bool {
+	return auth.Cancel && !take.PaidOut && !shift.Over() && shift.AfterDeadline(auth.CancelDeadline, time.Now()) && (auth.TakerNameAll || slices.Contains(auth.TakerName, take.Name))
+}
+
 func (auth Auth) CanEdit(shiftname string) bool {
 	return auth.EditAll || containsFold(auth.Edit, shiftname)
 }
@@ -162,12 +194,42 @@ func (auth Auth) CanEditAnyShift() bool {
 	return auth.EditAll || len(auth.Edit) > 0
 }
 
-func (auth Auth) CanPayout() bool {
-	return auth.PayoutAll // || containsFold(auth.Payout, shiftname)
+// CanOfferTake returns true if the take is approved, has not been paid out, and belongs to one of auth.TakerName.
+func (auth Auth) CanOfferTake(shift Shift, take Take) bool {
+	return auth.CanTake(shift.Name) && take.Approved && !take.PaidOut && !shift.Over() && (auth.TakerNameAll || slices.Contains(auth.TakerName, take.Name))
 }
 
+func (auth Auth) CanPayout(shiftname string) bool {
+	return auth.PayoutAll || containsFold(auth.Payout, shiftname)
+}
+
+func (auth Auth) CanPayoutAnyShift() bool {
+	return auth.PayoutAll || len(auth.Payout) > 0
+}
+
+// CanPayoutTake returns true if the shift is over and the take is approved, has not been paid out and is not a no-show.
 func (auth Auth) CanPayoutTake(shift Shift, take Take) bool {
-	return auth.CanPayout() && shift.Over() && take.Name != "" && take.Approved && !take.PaidOut
+	return auth.CanPayout(shift.Name) && shift.Over() && take.Name != "" && take.Approved && !take.PaidOut && take.Attendance != NoShow
+}
+
+// CanRecordTake returns true if the shift is over, the take is approved and has not been paid out, and auth can edit the shift or the take belongs to one of auth.TakerName.
+func (auth Auth) CanRecordTake(shift Shift, take Take) bool {
+	return shift.Over() && take.Approved && !take.PaidOut && (auth.CanEdit(shift.Name) || (auth.Record && (auth.TakerNameAll || slices.Contains(auth.TakerName, take.Name))))
+}
+
+// CanRejectTake returns true if auth could approve the take (see CanTakerName), ignoring whether the shift is fully taken.
+func (auth Auth) CanRejectTake(shift Shift, take Take) bool {
+	return auth.CanTake(shift.Name) && !shift.Over() && !take.Approved && !take.Rejected && (auth.TakerNameAll || slices.Contains(auth.TakerName, take.Name))
+}
+
+// CanLeaveWaitlist returns true if auth can edit the shift or the waiter is one of auth.TakerName.
+func (auth Auth) CanLeaveWaitlist(shift Shift, waiter Waiter) bool {
+	return !shift.Over() && (auth.CanEdit(shift.Name) || slices.Contains(auth.TakerName, waiter.Name) || (auth.TakerNameAll && (auth.CanTake(shift.Name) || auth.CanApply(shift.Name))))
+}
+
+// CanSetAttendance returns true if auth can edit the shift, the shift is over, and the take is approved and has not been paid out.
+func (auth Auth) CanSetAttendance(shift Shift, take Take) bool {
+	return auth.CanEdit(shift.Name) && shift.Over() && take.Approved && !take.PaidOut
 }
 
 func (auth Auth) CanTake(shiftname string) bool {
@@ -182,6 +244,30 @@ func (auth Auth) CanTakerName(shift Shift, name string) bool {
 	return auth.CanTakeShift(shift) && (auth.TakerNameAll || slices.Contains(auth.TakerName, name))
 }
 
+// CanUndoPayout returns true if auth can view the payout and it has not been undone yet.
+func (auth Auth) CanUndoPayout(payout Payout) bool {
+	return auth.CanViewPayout(payout) && !payout.Undone()
+}
+
+// CanViewPayout returns true if auth can pay out all shift names of the payout.
+func (auth Auth) CanViewPayout(payout Payout) bool {
+	for _, take := range payout.Takes {
+		if !auth.CanPayout(take.ShiftName) {
+			return false
+		}
+	}
+	return auth.CanPayoutAnyShift()
+}
+
+// CanWaitShift returns true if the shift is fully taken and auth could take or apply for it otherwise.
+func (auth Auth) CanWaitShift(shift Shift) bool {
+	return (auth.CanTake(shift.Name) || auth.CanApply(shift.Name)) && shift.FullyTaken() && !shift.Over() && shift.AfterDeadline(auth.TakeDeadline, time.Now()) && (auth.TakerNameAll || len(auth.TakerName) > 0)
+}
+
+func (auth Auth) CanWaitName(shift Shift, name string) bool {
+	return auth.CanWaitShift(shift) && (auth.TakerNameAll || slices.Contains(auth.TakerName, name))
+}
+
 // CheckBeginEnd checks that begin or end are non-zero, that end is after begin (or end is zero), and that begin and end are not too far in the past and future.
 func CheckBeginEnd(begin, end time.Time, pastAlways bool, future time.Duration) error {
 	// basics
@@ -227,6 +313,12 @@ func (auth Auth) Encode() []byte {
 		} else {
 			values["apply"] = auth.Apply
 		}
+		if auth.Cancel {
+			values.Set("cancel", "1")
+		}
+		if auth.CancelDeadline != "" {
+			values.Set("cancel-deadline", auth.CancelDeadline)
+		}
 		if auth.EditAll {
 			values.Set("edit-all", "1")
 		} else {
@@ -237,6 +329,11 @@ func (auth Auth) Encode() []byte {
 		}
 		if auth.PayoutAll {
 			values.Set("payout-all", "1")
+		} else {
+			values["payout"] = auth.Payout
+		}
+		if auth.Record {
+			values.Set("record", "1")
 		}
 		if auth.TakeAll {
 			values.Set("take-all", "1")
@@ -277,9 +374,11 @@ func (ref Auth) Restrict(input Auth) Auth {
 	// && bool
 	input.Admin = input.Admin && ref.Admin
 	input.ApplyAll = input.ApplyAll && ref.ApplyAll
+	input.Cancel = input.Cancel && ref.Cancel
 	input.EditAll = input.EditAll && ref.EditAll
 	input.EditRetroAlways = input.EditRetroAlways && ref.EditRetroAlways
 	input.PayoutAll = input.PayoutAll && ref.PayoutAll
+	input.Record = input.Record && ref.Record
 	input.TakeAll = input.TakeAll && ref.TakeAll
 	input.TakerNameAll = input.TakerNameAll && ref.TakerNameAll
 	input.ViewTakerContact = input.ViewTakerContact && ref.ViewTakerContact
@@ -291,6 +390,9 @@ func (ref Auth) Restrict(input Auth) Auth {
 	if !ref.EditAll && !input.EditAll {
 		input.Edit = Intersect(input.Edit, ref.Edit)
 	}
+	if !ref.PayoutAll && !input.PayoutAll {
+		input.Payout = Intersect(input.Payout, ref.Payout)
+	}
 	if !ref.TakeAll && !input.TakeAll {
 		input.Take = Intersect(input.Take, ref.Take)
 	}
@@ -304,6 +406,9 @@ func (ref Auth) Restrict(input Auth) Auth {
 		}
 	}
 	// overwrite
+	if ref.CancelDeadline != "" {
+		input.CancelDeadline = ref.CancelDeadline
+	}
 	if ref.TakeDeadline != "" {
 		input.TakeDeadline = ref.TakeDeadline
 	}
@@ -0,0 +1,28 @@
+package shiftpad
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPayoutAuth(t *testing.T) {
+	auth := Auth{Payout: []string{"Bar", "Kitchen"}}
+	decoded, err := DecodeAuth(string(auth.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(decoded.Payout, auth.Payout) || decoded.PayoutAll {
+		t.Fatalf("got %+v", decoded)
+	}
+	if !decoded.CanPayout("bar") || decoded.CanPayout("Door") || !decoded.CanPayoutAnyShift() {
+		t.Fatalf("wrong payout permissions: %+v", decoded)
+	}
+
+	restricted := decoded.Restrict(Auth{Payout: []string{"Bar", "Door"}, PayoutAll: true})
+	if restricted.PayoutAll || !slices.Equal(restricted.Payout, []string{"Bar"}) {
+		t.Fatalf("restrict: got %+v", restricted)
+	}
+	if all := (Auth{PayoutAll: true}).Restrict(Auth{Payout: []string{"Door"}}); !slices.Equal(all.Payout, []string{"Door"}) {
+		t.Fatalf("restrict to payout all: got %+v", all)
+	}
+}
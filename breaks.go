@@ -0,0 +1,58 @@
+package shiftpad
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BreakRule requires an unpaid break for shifts which are longer than a given duration.
+// Break rules are written like "30m after 6h".
+type BreakRule struct {
+	Break time.Duration
+	After time.Duration // shift length
+}
+
+func ParseBreakRule(s string) (BreakRule, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 || !strings.EqualFold(fields[1], "after") {
+		return BreakRule{}, errors.New(`break rule must look like "30m after 6h"`)
+	}
+	brk, err := time.ParseDuration(fields[0])
+	if err != nil || brk <= 0 {
+		return BreakRule{}, fmt.Errorf("invalid break: %s", fields[0])
+	}
+	after, err := time.ParseDuration(fields[2])
+	if err != nil || after < 0 {
+		return BreakRule{}, fmt.Errorf("invalid shift length: %s", fields[2])
+	}
+	return BreakRule{Break: brk, After: after}, nil
+}
+
+func (rule BreakRule) String() string {
+	return formatDuration(rule.Break) + " after " + formatDuration(rule.After)
+}
+
+// formatDuration formats a duration without trailing zero units, like "6h" or "1h30m".
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// MinBreak returns the longest break which the break rules of the pad require for a shift of the given length.
+func (pad Pad) MinBreak(length time.Duration) time.Duration {
+	var result time.Duration
+	for _, rule := range pad.BreakRules {
+		if length > rule.After {
+			result = max(result, rule.Break)
+		}
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBreakRule(t *testing.T) {
+	for _, s := range []string{"30m after 6h", "45m after 9h", "15m after 4h30m", "10s after 1m20s", "1m30s after 1h0m10s"} {
+		rule, err := ParseBreakRule(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if got := rule.String(); got != s {
+			t.Fatalf("got %q, want %q", got, s)
+		}
+	}
+	for _, s := range []string{"", "30m", "30m for 6h", "0m after 6h", "30 after 6h", "30m after -1h"} {
+		if _, err := ParseBreakRule(s); err == nil {
+			t.Fatalf("%q: got no error", s)
+		}
+	}
+}
+
+func TestMinBreak(t *testing.T) {
+	pad := Pad{BreakRules: []BreakRule{
+		{Break: 30 * time.Minute, After: 6 * time.Hour},
+		{Break: 45 * time.Minute, After: 9 * time.Hour},
+	}}
+	tests := []struct {
+		length time.Duration
+		want   time.Duration
+	}{
+		{4 * time.Hour, 0},
+		{6 * time.Hour, 0},
+		{7 * time.Hour, 30 * time.Minute},
+		{10 * time.Hour, 45 * time.Minute},
+	}
+	for _, test := range tests {
+		if got := pad.MinBreak(test.length); got != test.want {
+			t.Fatalf("%v: got %v, want %v", test.length, got, test.want)
+		}
+	}
+
+	begin := time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC)
+	shift := Shift{Begin: begin, End: begin.Add(7 * time.Hour), Break: pad.MinBreak(7 * time.Hour)}
+	if got := shift.Hours(); got != 6.5 {
+		t.Fatalf("got %v net hours, want 6.5", got)
+	}
+}
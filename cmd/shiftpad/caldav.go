@@ -0,0 +1,480 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/wansing/shiftpad"
+)
+
+// CalDAV (RFC 4791) access to the shifts of a pad. Each share link has a calendar collection at <link>/caldav/ which contains a calendar object resource <shift id>.ics for each shift.
+// Shifts which have been created by a calendar client keep the resource name which the client has chosen.
+// Like the iCalendar feed, the calendar is read-only unless the share can edit shifts. Then shifts can be created and moved from calendar clients.
+// There is no HTTP authentication, the secret of the share link is the credential.
+
+const calDAVPast = 90 * 24 * time.Hour // shifts which begin before are not listed, unless a time range is queried
+
+const nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+
+type davMultistatus struct {
+	XMLName   xml.Name      `xml:"DAV: multistatus"`
+	Responses []davResponse `xml:"DAV: response"`
+}
+
+type davResponse struct {
+	Href     string       `xml:"DAV: href"`
+	Propstat *davPropstat `xml:"DAV: propstat,omitempty"`
+	Status   string       `xml:"DAV: status,omitempty"` // if the resource is not found
+}
+
+type davPropstat struct {
+	Prop   davProp `xml:"DAV: prop"`
+	Status string  `xml:"DAV: status"`
+}
+
+type davProp struct {
+	ResourceType            *davResourceType `xml:"DAV: resourcetype,omitempty"`
+	DisplayName             string           `xml:"DAV: displayname,omitempty"`
+	CurrentUserPrincipal    *davHref         `xml:"DAV: current-user-principal,omitempty"`
+	CurrentUserPrivilegeSet *davPrivileges   `xml:"DAV: current-user-privilege-set,omitempty"`
+	CalendarHomeSet         *davHref         `xml:"urn:ietf:params:xml:ns:caldav calendar-home-set,omitempty"`
+	SupportedComponents     *davComponents   `xml:"urn:ietf:params:xml:ns:caldav supported-calendar-component-set,omitempty"`
+	CTag                    string           `xml:"http://calendarserver.org/ns/ getctag,omitempty"`
+	ContentType             string           `xml:"DAV: getcontenttype,omitempty"`
+	ETag                    string           `xml:"DAV: getetag,omitempty"`
+	LastModified            string           `xml:"DAV: getlastmodified,omitempty"`
+	CalendarData            string           `xml:"urn:ietf:params:xml:ns:caldav calendar-data,omitempty"`
+}
+
+type davResourceType struct {
+	Collection *struct{} `xml:"DAV: collection"`
+	Calendar   *struct{} `xml:"urn:ietf:params:xml:ns:caldav calendar"`
+}
+
+type davHref struct {
+	Href string `xml:"DAV: href"`
+}
+
+type davPrivileges struct {
+	Privileges []davPrivilege `xml:"DAV: privilege"`
+}
+
+type davPrivilege struct {
+	Read  *struct{} `xml:"DAV: read,omitempty"`
+	Write *struct{} `xml:"DAV: write,omitempty"`
+}
+
+type davComponents struct {
+	Comps []davComponent `xml:"urn:ietf:params:xml:ns:caldav comp"`
+}
+
+type davComponent struct {
+	Name string `xml:"name,attr"`
+}
+
+// calReport is the body of a calendar-query or calendar-multiget REPORT request.
+type calReport struct {
+	XMLName xml.Name
+	Hrefs   []string      `xml:"DAV: href"`                                        // calendar-multiget
+	Filter  calCompFilter `xml:"urn:ietf:params:xml:ns:caldav filter>comp-filter"` // calendar-query
+}
+
+type calCompFilter struct {
+	Name        string          `xml:"name,attr"`
+	CompFilters []calCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	TimeRange   *struct {
+		Start string `xml:"start,attr"`
+		End   string `xml:"end,attr"`
+	} `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+}
+
+// timeRange returns the time range of the first nested comp-filter which has one. Start and end are optional, so each of them can be zero.
+func (filter calCompFilter) timeRange() (time.Time, time.Time) {
+	if filter.TimeRange != nil {
+		start, _ := time.Parse("20060102T150405Z", filter.TimeRange.Start)
+		end, _ := time.Parse("20060102T150405Z", filter.TimeRange.End)
+		return start, end
+	}
+	for _, nested := range filter.CompFilters {
+		if start, end := nested.timeRange(); !start.IsZero() || !end.IsZero() {
+			return start, end
+		}
+	}
+	return time.Time{}, time.Time{}
+}
+
+func davError(code int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(code), code)
+	})
+}
+
+func shiftETag(shift shiftpad.Shift) string {
+	return fmt.Sprintf(`"%d-%d"`, shift.ID, shift.Modified.Unix())
+}
+
+// padCalDAV handles all methods on the calendar collection and its objects. Other than the GET handlers, it responds to errors with plain text, because calendar clients don't show html.
+func (srv *Server) padCalDAV(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	object := r.PathValue("object")
+	if object == "" {
+		switch r.Method {
+		case http.MethodOptions:
+			return calDAVOptions(w)
+		case http.MethodGet, http.MethodHead:
+			return srv.padICal(w, r, authpad)
+		case "PROPFIND":
+			return srv.calDAVPropfindCollection(w, r, authpad)
+		case "REPORT":
+			return srv.calDAVReport(w, r, authpad)
+		default:
+			return davError(http.StatusMethodNotAllowed)
+		}
+	}
+
+	switch r.Method {
+	case http.MethodOptions:
+		return calDAVOptions(w)
+	case http.MethodGet, http.MethodHead:
+		return srv.calDAVGet(w, r, authpad, object)
+	case "PROPFIND":
+		return srv.calDAVPropfindObject(w, r, authpad, object)
+	case http.MethodPut:
+		return srv.calDAVPut(w, r, authpad, object)
+	default:
+		return davError(http.StatusMethodNotAllowed) // deleting shifts is not supported
+	}
+}
+
+func calDAVOptions(w http.ResponseWriter) http.Handler {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", "OPTIONS, GET, HEAD, PUT, PROPFIND, REPORT")
+	return nil
+}
+
+// calDAVShift returns the shift which a client has created with the object name, or else the shift of the object name like "123.ics".
+func (srv *Server) calDAVShift(authpad shiftpad.AuthPad, object string) (*shiftpad.Shift, bool) {
+	if shift, err := srv.DB.GetCalDAVShift(authpad.Pad, object); err == nil {
+		return shift, true
+	}
+	id, ok := shiftObjectID(object)
+	if !ok {
+		return nil, false
+	}
+	shift, err := srv.DB.GetShift(authpad.Pad, id)
+	if err != nil {
+		return nil, false
+	}
+	return shift, true
+}
+
+// shiftObjectID returns the shift id of an object name like "123.ics".
+func shiftObjectID(object string) (int, bool) {
+	id, err := strconv.Atoi(strings.TrimSuffix(object, ".ics"))
+	return id, err == nil && strings.HasSuffix(object, ".ics")
+}
+
+// objectName returns the resource name of the shift. The objects are from GetCalDAVObjects.
+func objectName(objects map[int]string, shift shiftpad.Shift) string {
+	if name, ok := objects[shift.ID]; ok {
+		return name
+	}
+	return fmt.Sprintf("%d.ics", shift.ID)
+}
+
+// calDAVShifts returns the shifts which begin between from and to. Zero values are replaced by the default range, and the range is limited to maxICalRange.
+func (srv *Server) calDAVShifts(authpad shiftpad.AuthPad, from, to time.Time) ([]shiftpad.Shift, error) {
+	now := time.Now()
+	if from.IsZero() {
+		from = now.Add(-calDAVPast)
+	}
+	if to.IsZero() || to.After(now.Add(shiftpad.MaxFuture)) {
+		to = now.Add(shiftpad.MaxFuture)
+	}
+	if to.Sub(from) > maxICalRange {
+		from = to.Add(-maxICalRange) // clients are interested in recent shifts rather than in old ones
+	}
+	return srv.DB.GetShifts(authpad.Pad, from.Unix(), to.Unix())
+}
+
+// calendarData returns an iCalendar object which contains the shift.
+func (srv *Server) calendarData(r *http.Request, authpad shiftpad.AuthPad, shift shiftpad.Shift, eventSummaries map[string]string) (string, error) {
+	cal := newCalendar(authpad.Location, shift.Begin, shift.End)
+	event := shiftEvent(authpad, shift, shift.TakeViews(authpad.Auth), baseURL(r), eventSummaries[shift.EventUID])
+	cal.Children = append(cal.Children, event.Component)
+
+	var buf bytes.Buffer
+	if err := ical.NewEncoder(&buf).Encode(cal); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (srv *Server) calDAVGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, object string) http.Handler {
+	shift, ok := srv.calDAVShift(authpad, object)
+	if !ok {
+		return davError(http.StatusNotFound)
+	}
+	data, err := srv.calendarData(r, authpad, *shift, srv.eventSummaries(authpad))
+	if err != nil {
+		return InternalServerError(err)
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("ETag", shiftETag(*shift))
+	w.Header().Set("Last-Modified", shift.Modified.UTC().Format(http.TimeFormat))
+	if r.Method == http.MethodGet {
+		io.WriteString(w, data)
+	}
+	return nil
+}
+
+func collectionResponse(authpad shiftpad.AuthPad, shifts []shiftpad.Shift) davResponse {
+	// the ctag changes if any shift is added, modified or deleted
+	hash := sha256.New()
+	for _, shift := range shifts {
+		fmt.Fprintf(hash, "%d-%d\n", shift.ID, shift.Modified.Unix())
+	}
+
+	name := authpad.Name
+	if name == "" {
+		name = "shiftpad"
+	}
+
+	privileges := []davPrivilege{{Read: &struct{}{}}}
+	if authpad.CanEditAnyShift() {
+		privileges = append(privileges, davPrivilege{Write: &struct{}{}})
+	}
+
+	href := authpad.Link() + "/caldav/"
+	return davResponse{
+		Href: href,
+		Propstat: &davPropstat{
+			Prop: davProp{
+				ResourceType:            &davResourceType{Collection: &struct{}{}, Calendar: &struct{}{}},
+				DisplayName:             name,
+				CurrentUserPrincipal:    &davHref{href}, // there are no principals, so clients find the calendar right here
+				CurrentUserPrivilegeSet: &davPrivileges{privileges},
+				CalendarHomeSet:         &davHref{href},
+				SupportedComponents:     &davComponents{[]davComponent{{Name: ical.CompEvent}}},
+				CTag:                    fmt.Sprintf(`"%x"`, hash.Sum(nil)),
+			},
+			Status: "HTTP/1.1 200 OK",
+		},
+	}
+}
+
+func objectResponse(authpad shiftpad.AuthPad, object string, shift shiftpad.Shift, calendarData string) davResponse {
+	return davResponse{
+		Href: authpad.Link() + "/caldav/" + url.PathEscape(object),
+		Propstat: &davPropstat{
+			Prop: davProp{
+				ContentType:  "text/calendar; charset=utf-8; component=VEVENT",
+				ETag:         shiftETag(shift),
+				LastModified: shift.Modified.UTC().Format(http.TimeFormat),
+				CalendarData: calendarData,
+			},
+			Status: "HTTP/1.1 200 OK",
+		},
+	}
+}
+
+// calDAVPropfindCollection returns the properties of the calendar collection, and with "Depth: 1" the properties of its shifts.
+// Requested properties are not considered, because the set of properties is small.
+func (srv *Server) calDAVPropfindCollection(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	shifts, err := srv.calDAVShifts(authpad, time.Time{}, time.Time{})
+	if err != nil {
+		return InternalServerError(err)
+	}
+	objects, err := srv.DB.GetCalDAVObjects(authpad.Pad)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	var multistatus davMultistatus
+	multistatus.Responses = append(multistatus.Responses, collectionResponse(authpad, shifts))
+	if r.Header.Get("Depth") == "1" {
+		for _, shift := range shifts {
+			multistatus.Responses = append(multistatus.Responses, objectResponse(authpad, objectName(objects, shift), shift, ""))
+		}
+	}
+	return writeMultistatus(w, multistatus)
+}
+
+func (srv *Server) calDAVPropfindObject(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, object string) http.Handler {
+	shift, ok := srv.calDAVShift(authpad, object)
+	if !ok {
+		return davError(http.StatusNotFound)
+	}
+	return writeMultistatus(w, davMultistatus{
+		Responses: []davResponse{objectResponse(authpad, object, *shift, "")},
+	})
+}
+
+// calDAVReport answers calendar-query and calendar-multiget reports. Both return calendar data.
+// Filters of calendar-query except for the time range are not considered, because all objects are events.
+func (srv *Server) calDAVReport(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	var report calReport
+	if err := xml.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&report); err != nil {
+		return davError(http.StatusBadRequest)
+	}
+
+	var multistatus davMultistatus
+	eventSummaries := srv.eventSummaries(authpad)
+	switch report.XMLName {
+	case xml.Name{Space: nsCalDAV, Local: "calendar-query"}:
+		from, to := report.Filter.timeRange()
+		shifts, err := srv.calDAVShifts(authpad, from, to)
+		if err != nil {
+			return InternalServerError(err)
+		}
+		objects, err := srv.DB.GetCalDAVObjects(authpad.Pad)
+		if err != nil {
+			return InternalServerError(err)
+		}
+		for _, shift := range shifts {
+			data, err := srv.calendarData(r, authpad, shift, eventSummaries)
+			if err != nil {
+				return InternalServerError(err)
+			}
+			multistatus.Responses = append(multistatus.Responses, objectResponse(authpad, objectName(objects, shift), shift, data))
+		}
+	case xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}:
+		for _, href := range report.Hrefs {
+			object, _ := url.PathUnescape(path.Base(href))
+			shift, ok := srv.calDAVShift(authpad, object)
+			if !ok || !strings.HasPrefix(href, authpad.Link()+"/caldav/") {
+				multistatus.Responses = append(multistatus.Responses, davResponse{
+					Href:   href,
+					Status: "HTTP/1.1 404 Not Found",
+				})
+				continue
+			}
+			data, err := srv.calendarData(r, authpad, *shift, eventSummaries)
+			if err != nil {
+				return InternalServerError(err)
+			}
+			multistatus.Responses = append(multistatus.Responses, objectResponse(authpad, object, *shift, data))
+		}
+	default:
+		return davError(http.StatusNotImplemented) // like sync-collection, which is not announced
+	}
+	return writeMultistatus(w, multistatus)
+}
+
+func writeMultistatus(w http.ResponseWriter, multistatus davMultistatus) http.Handler {
+	out, err := xml.Marshal(multistatus)
+	if err != nil {
+		return InternalServerError(err)
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	io.WriteString(w, xml.Header)
+	w.Write(out)
+	return nil
+}
+
+// calDAVPut creates or moves a shift, with the same permission checks as shiftAddPost and shiftEditPost. The begin, end and name of the shift are taken from the first event, other properties are ignored.
+// The resource name which the client has chosen for a new shift is stored, so the shift is listed under that name and repeated requests don't create duplicates.
+// Names like "<id>.ics" are reserved for shifts which have been created in the web interface.
+func (srv *Server) calDAVPut(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, object string) http.Handler {
+	if !authpad.CanEditAnyShift() {
+		return davError(http.StatusForbidden)
+	}
+
+	cal, err := ical.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode()
+	if err != nil {
+		return davError(http.StatusBadRequest)
+	}
+	events := cal.Events()
+	if len(events) == 0 {
+		return davError(http.StatusBadRequest)
+	}
+	event := events[0]
+	begin, err := event.DateTimeStart(authpad.Location)
+	if err != nil {
+		return davError(http.StatusBadRequest)
+	}
+	end, err := event.DateTimeEnd(authpad.Location)
+	if err != nil {
+		return davError(http.StatusBadRequest)
+	}
+	begin = begin.In(authpad.Location)
+	end = end.In(authpad.Location)
+	name, _ := event.Props.Text(ical.PropSummary)
+	name = trim(name, 64)
+	if name == "" {
+		return davError(http.StatusBadRequest)
+	}
+
+	var status int
+	if shift, ok := srv.calDAVShift(authpad, object); ok {
+		// move an existing shift
+		if match := r.Header.Get("If-Match"); match != "" && match != shiftETag(*shift) {
+			return davError(http.StatusPreconditionFailed)
+		}
+		if r.Header.Get("If-None-Match") == "*" {
+			return davError(http.StatusPreconditionFailed)
+		}
+		if !authpad.CanEditShift(*shift) {
+			return davError(http.StatusForbidden)
+		}
+		original := *shift
+		shift.Name = name
+		shift.Begin = begin
+		shift.End = end
+		shift.Break = max(shift.Break, authpad.MinBreak(end.Sub(begin)))
+		shift.Modified = time.Now().In(authpad.Location)
+		shift.ResetRecorded(original)
+		if !authpad.CanEditShift(*shift) || shift.Break >= end.Sub(begin) {
+			return davError(http.StatusForbidden)
+		}
+		if errs, _, err := srv.checkTakes(authpad, original, *shift); err != nil {
+			return InternalServerError(err)
+		} else if len(errs) > 0 {
+			return davError(http.StatusForbidden) // like overlaps or limits of a taker
+		}
+		if err := srv.DB.UpdateShift(authpad.Pad, shift); err != nil {
+			return InternalServerError(err)
+		}
+		w.Header().Set("ETag", shiftETag(*shift))
+		status = http.StatusNoContent
+	} else {
+		// create a new shift
+		if r.Header.Get("If-Match") != "" {
+			return davError(http.StatusPreconditionFailed)
+		}
+		shift := shiftpad.Shift{
+			Modified: time.Now().In(authpad.Location),
+			Name:     name,
+			Quantity: 1,
+			Begin:    begin,
+			End:      end,
+			Break:    authpad.MinBreak(end.Sub(begin)),
+		}
+		if _, reserved := shiftObjectID(object); reserved || len(object) > 255 {
+			return davError(http.StatusForbidden)
+		}
+		if !authpad.CanEditShift(shift) || shift.Break >= end.Sub(begin) {
+			return davError(http.StatusForbidden)
+		}
+		if err := srv.DB.AddCalDAVShift(authpad.Pad, shift, object); err != nil {
+			return InternalServerError(err)
+		}
+		status = http.StatusCreated
+	}
+
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+	w.WriteHeader(status)
+	return nil
+}
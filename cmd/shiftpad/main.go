@@ -1,6 +1,9 @@
 package main
 
 import (
+	"crypto/sha1"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -68,11 +71,29 @@ func main() {
 	mux.Handle("GET  /p/{pad}/{secret}/payout/{taker}", srv.withPad(srv.padPayoutTakerGet))
 	mux.Handle("POST /p/{pad}/{secret}/payout/{taker}", srv.withPad(srv.padPayoutTakerPost))
 	mux.Handle("GET  /p/{pad}/{secret}/payout/{taker}/result", srv.withPad(srv.padPayoutTakerResultGet))
+	mux.Handle("GET  /p/{pad}/{secret}/payout-report", srv.withPad(srv.padPayoutReportGet))
+	mux.Handle("GET  /p/{pad}/{secret}/payout-report/csv", srv.withPad(srv.padPayoutReportCSV))
+	mux.Handle("GET  /p/{pad}/{secret}/payouts", srv.withPad(srv.padPayoutsGet))
+	mux.Handle("GET  /p/{pad}/{secret}/payouts/{payout}/receipt", srv.withPayout(srv.payoutReceipt))
+	mux.Handle("GET  /p/{pad}/{secret}/undo-payout/{payout}", srv.withPayout(srv.payoutUndoGet))
+	mux.Handle("POST /p/{pad}/{secret}/undo-payout/{payout}", srv.withPayout(srv.payoutUndoPost))
 	mux.Handle("GET  /p/{pad}/{secret}/settings", srv.withPad(srv.padSettingsGet))
 	mux.Handle("POST /p/{pad}/{secret}/settings", srv.withPad(srv.padSettingsPost))
 	mux.Handle("GET  /p/{pad}/{secret}/share", srv.withPad(srv.padShareGet))
 	mux.Handle("POST /p/{pad}/{secret}/share", srv.withPad(srv.padSharePost))
+	mux.Handle("GET  /p/{pad}/{secret}/shares", srv.withPad(srv.padSharesGet))
+	mux.Handle("GET  /p/{pad}/{secret}/edit-share/{share}", srv.withShare(srv.shareEditGet))
+	mux.Handle("POST /p/{pad}/{secret}/edit-share/{share}", srv.withShare(srv.shareEditPost))
+	mux.Handle("GET  /p/{pad}/{secret}/delete-share/{share}", srv.withShare(srv.shareDeleteGet))
+	mux.Handle("POST /p/{pad}/{secret}/delete-share/{share}", srv.withShare(srv.shareDeletePost))
+	mux.Handle("GET  /p/{pad}/{secret}/conflicts", srv.withPad(srv.padConflictsGet))
+	mux.Handle("GET  /p/{pad}/{secret}/hours", srv.withPad(srv.padHoursGet))
+	mux.Handle("GET  /p/{pad}/{secret}/attendance", srv.withPad(srv.padAttendanceGet))
 	mux.Handle("GET  /p/{pad}/{secret}/ical", srv.withPad(srv.padICal))
+	mux.Handle("/p/{pad}/{secret}/caldav", srv.withPad(srv.padCalDAV)) // all methods, see padCalDAV
+	mux.Handle("/p/{pad}/{secret}/caldav/{object...}", srv.withPad(srv.padCalDAV))
+	mux.Handle("GET  /p/{pad}/{secret}/my-shifts", srv.withPad(srv.padMyShiftsGet))
+	mux.Handle("GET  /p/{pad}/{secret}/my-shifts/ical", srv.withPad(srv.padMyShiftsICal))
 	mux.Handle("GET  /p/{pad}/{secret}/day/{date}", srv.withPad(srv.padViewDay))
 	mux.Handle("GET  /p/{pad}/{secret}/month", srv.withPad(srv.padViewCurrentMonthGet))
 	mux.Handle("GET  /p/{pad}/{secret}/month/{year}/{month}", srv.withPad(srv.padViewMonthGet))
@@ -82,10 +103,32 @@ func main() {
 	mux.Handle("POST /p/{pad}/{secret}/week/{year}/{week}", srv.withPad(srv.padViewPost)) // same handler as /month
 	mux.Handle("GET  /p/{pad}/{secret}/add/{date}", srv.withPad(srv.shiftAddGet))
 	mux.Handle("POST /p/{pad}/{secret}/add/{date}", srv.withPad(srv.shiftAddPost))
+	mux.Handle("GET  /p/{pad}/{secret}/copy-day/{date}", srv.withPad(srv.shiftCopyDayGet))
+	mux.Handle("POST /p/{pad}/{secret}/copy-day/{date}", srv.withPad(srv.shiftCopyDayPost))
+	mux.Handle("GET  /p/{pad}/{secret}/copy-week/{year}/{week}", srv.withPad(srv.shiftCopyWeekGet))
+	mux.Handle("POST /p/{pad}/{secret}/copy-week/{year}/{week}", srv.withPad(srv.shiftCopyWeekPost))
 	mux.Handle("GET  /p/{pad}/{secret}/approve/{shift}/{take}", srv.withTake(srv.takeApproveGet))
 	mux.Handle("POST /p/{pad}/{secret}/approve/{shift}/{take}", srv.withTake(srv.takeApprovePost))
+	mux.Handle("GET  /p/{pad}/{secret}/reject/{shift}/{take}", srv.withTake(srv.takeRejectGet))
+	mux.Handle("POST /p/{pad}/{secret}/reject/{shift}/{take}", srv.withTake(srv.takeRejectPost))
+	mux.Handle("GET  /p/{pad}/{secret}/cancel/{shift}/{take}", srv.withTake(srv.takeCancelGet))
+	mux.Handle("POST /p/{pad}/{secret}/cancel/{shift}/{take}", srv.withTake(srv.takeCancelPost))
+	mux.Handle("GET  /p/{pad}/{secret}/attendance/{shift}/{take}", srv.withTake(srv.takeAttendanceGet))
+	mux.Handle("POST /p/{pad}/{secret}/attendance/{shift}/{take}", srv.withTake(srv.takeAttendancePost))
+	mux.Handle("GET  /p/{pad}/{secret}/record/{shift}/{take}", srv.withTake(srv.takeRecordGet))
+	mux.Handle("POST /p/{pad}/{secret}/record/{shift}/{take}", srv.withTake(srv.takeRecordPost))
+	mux.Handle("GET  /p/{pad}/{secret}/offer/{shift}/{take}", srv.withTake(srv.takeOfferGet))
+	mux.Handle("POST /p/{pad}/{secret}/offer/{shift}/{take}", srv.withTake(srv.takeOfferPost))
+	mux.Handle("GET  /p/{pad}/{secret}/withdraw/{shift}/{take}", srv.withTake(srv.takeWithdrawGet))
+	mux.Handle("POST /p/{pad}/{secret}/withdraw/{shift}/{take}", srv.withTake(srv.takeWithdrawPost))
+	mux.Handle("GET  /p/{pad}/{secret}/accept/{shift}/{take}", srv.withTake(srv.takeAcceptGet))
+	mux.Handle("POST /p/{pad}/{secret}/accept/{shift}/{take}", srv.withTake(srv.takeAcceptPost))
 	mux.Handle("GET  /p/{pad}/{secret}/take/{shift}", srv.withShift(srv.shiftTakeGet))
 	mux.Handle("POST /p/{pad}/{secret}/take/{shift}", srv.withShift(srv.shiftTakePost))
+	mux.Handle("GET  /p/{pad}/{secret}/wait/{shift}", srv.withShift(srv.shiftWaitGet))
+	mux.Handle("POST /p/{pad}/{secret}/wait/{shift}", srv.withShift(srv.shiftWaitPost))
+	mux.Handle("GET  /p/{pad}/{secret}/leave/{shift}/{waiter}", srv.withWaiter(srv.waiterLeaveGet))
+	mux.Handle("POST /p/{pad}/{secret}/leave/{shift}/{waiter}", srv.withWaiter(srv.waiterLeavePost))
 	mux.Handle("GET  /p/{pad}/{secret}/edit/{shift}", srv.withShift(srv.shiftEditGet))
 	mux.Handle("POST /p/{pad}/{secret}/edit/{shift}", srv.withShift(srv.shiftEditPost))
 	mux.Handle("GET  /p/{pad}/{secret}/delete/{shift}", srv.withShift(srv.shiftDeleteGet))
@@ -95,6 +138,15 @@ func main() {
 	http.ListenAndServe("127.0.0.1:8200", srv.sessionManager.LoadAndSave(mux))
 }
 
+// baseURL returns the scheme and host of the request, like "https://example.com".
+func baseURL(r *http.Request) string {
+	var scheme = "https"
+	if r.Host == "127.0.0.1" || strings.HasPrefix(r.Host, "127.0.0.1:") || strings.HasSuffix(r.Host, ".onion") {
+		scheme = "http"
+	}
+	return scheme + "://" + r.Host
+}
+
 func linkDay(authpad shiftpad.AuthPad, t time.Time) string {
 	return fmt.Sprintf("%s/day/%s", authpad.Link(), t.Format("2006-01-02"))
 }
@@ -150,6 +202,58 @@ func (srv *Server) withTake(f func(http.ResponseWriter, *http.Request, shiftpad.
 	})
 }
 
+func (srv *Server) withWaiter(f func(http.ResponseWriter, *http.Request, shiftpad.AuthPad, *shiftpad.Shift, shiftpad.Waiter) http.Handler) HandlerFunc {
+	return srv.withShift(func(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+		id, _ := strconv.Atoi(r.PathValue("waiter"))
+		// linear search
+		for _, waiter := range shift.Waitlist {
+			if waiter.ID == id {
+				return f(w, r, authpad, shift, waiter)
+			}
+		}
+		return NotFound()
+	})
+}
+
+// withPayout calls handlers with a pad and a payout which auth can view.
+func (srv *Server) withPayout(f func(http.ResponseWriter, *http.Request, shiftpad.AuthPad, shiftpad.Payout) http.Handler) HandlerFunc {
+	return srv.withPad(func(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+		id, _ := strconv.Atoi(r.PathValue("payout"))
+		payout, err := srv.DB.GetPayout(authpad.Pad, id)
+		if err != nil {
+			return NotFound()
+		}
+		if !authpad.CanViewPayout(payout) {
+			return NotFound()
+		}
+		return f(w, r, authpad, payout)
+	})
+}
+
+// withShare calls handlers with an admin pad and another share of that pad. The share of the caller can't be edited or deleted, so admins don't lock themselves out.
+func (srv *Server) withShare(f func(http.ResponseWriter, *http.Request, shiftpad.AuthPad, shiftpad.Share) http.Handler) HandlerFunc {
+	return srv.withPad(func(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+		if !authpad.Admin {
+			return NotFound()
+		}
+		secret := r.PathValue("share")
+		if secret == authpad.Secret {
+			return NotFound()
+		}
+		shares, err := srv.DB.GetShares(authpad.Pad)
+		if err != nil {
+			return InternalServerError(err)
+		}
+		// linear search
+		for _, share := range shares {
+			if share.Secret == secret {
+				return f(w, r, authpad, share)
+			}
+		}
+		return NotFound()
+	})
+}
+
 func Forbidden() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusForbidden)
@@ -210,11 +314,11 @@ func (srv *Server) createPost(w http.ResponseWriter, r *http.Request) http.Handl
 }
 
 func (srv *Server) padPayoutGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	if !authpad.CanPayout() {
+	if !authpad.CanPayoutAnyShift() {
 		return NotFound()
 	}
 
-	takerNames, err := srv.DB.GetTakerNames(authpad.Pad)
+	takerNames, err := srv.DB.GetTakerNames(authpad.Pad, authpad.CanPayout)
 	if err != nil {
 		return InternalServerError(err)
 	}
@@ -234,7 +338,7 @@ func (srv *Server) padPayoutGet(w http.ResponseWriter, r *http.Request, authpad
 }
 
 func (srv *Server) padPayoutTakerGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	if !authpad.CanPayout() {
+	if !authpad.CanPayoutAnyShift() {
 		return NotFound()
 	}
 
@@ -246,6 +350,9 @@ func (srv *Server) padPayoutTakerGet(w http.ResponseWriter, r *http.Request, aut
 	}
 	// don't filter by CanPayoutTake because we also list takes which are paid out
 	// don't restrict to paid shifts because shift.paid may have been changed after payout
+	shifts = slices.DeleteFunc(shifts, func(shift shiftpad.Shift) bool {
+		return !authpad.CanPayout(shift.Name)
+	})
 
 	// group consecutive shifts by event
 	var events []shiftpad.Event
@@ -278,14 +385,33 @@ func (srv *Server) padPayoutTakerGet(w http.ResponseWriter, r *http.Request, aut
 		}
 	}
 
+	// sum takes which can be paid out
+	var open shiftpad.Payout
+	var openSurcharges []shiftpad.SurchargeHours
+	for _, event := range events {
+		for _, shift := range event.Shifts {
+			for _, take := range shift.Takes {
+				if authpad.CanPayoutTake(shift, take) {
+					open.Add(authpad.Pad, shift, take)
+					openSurcharges = shiftpad.AddSurchargeHours(openSurcharges, authpad.SurchargeHours(shift.Worked(take)))
+				}
+			}
+		}
+	}
+
+	errs, _ := srv.sessionManager.Pop(r.Context(), "errs").([]string)
+
 	err = html.PadPayoutTaker.Execute(w, html.PadPayoutTakerData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
 			ActiveTab:  "payout",
 			Pad:        authpad,
+			Errors:     errs,
 		},
-		Name:   takerName,
-		Events: events,
+		Name:           takerName,
+		Events:         events,
+		Open:           open,
+		OpenSurcharges: openSurcharges,
 	})
 	if err != nil {
 		return InternalServerError(err)
@@ -294,7 +420,7 @@ func (srv *Server) padPayoutTakerGet(w http.ResponseWriter, r *http.Request, aut
 }
 
 func (srv *Server) padPayoutTakerPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	if !authpad.CanPayout() {
+	if !authpad.CanPayoutAnyShift() {
 		return NotFound()
 	}
 
@@ -314,28 +440,37 @@ func (srv *Server) padPayoutTakerPost(w http.ResponseWriter, r *http.Request, au
 	if err != nil {
 		return InternalServerError(err)
 	}
-	var updateTakes []shiftpad.Take
+	var payout = shiftpad.Payout{
+		Created: time.Now(),
+		Payer:   authpad.Note,
+		Taker:   takerName,
+	}
 	for _, shift := range shifts {
 		for _, take := range shift.Takes {
 			if authpad.CanPayoutTake(shift, take) {
 				if _, ok := setPaidOut[take.ID]; ok {
-					take.PaidOut = true
-					updateTakes = append(updateTakes, take)
+					payout.Add(authpad.Pad, shift, take)
 				}
 			}
 		}
 	}
-	if err := srv.DB.SetPaidOut(updateTakes); err != nil {
-		return InternalServerError(err)
-	}
-	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
-		return InternalServerError(err)
-	}
 
-	if len(updateTakes) > 0 {
+	if len(payout.Takes) > 0 {
+		payoutID, err := srv.DB.AddPayout(authpad.Pad, payout)
+		if errors.Is(err, shiftpad.ErrPaidOut) {
+			srv.sessionManager.Put(r.Context(), "errs", []string{err.Error()})
+			return http.RedirectHandler(r.URL.String(), http.StatusSeeOther) // the taker page shows which takes are paid out now
+		} else if err != nil {
+			return InternalServerError(err)
+		}
+		if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+			return InternalServerError(err)
+		}
+
 		var query = make(url.Values)
-		for _, updated := range updateTakes {
-			query.Add("paid_out", strconv.Itoa(updated.ID))
+		query.Set("payout", strconv.Itoa(payoutID))
+		for _, take := range payout.Takes {
+			query.Add("paid_out", strconv.Itoa(take.TakeID))
 		}
 		var u = *r.URL // copy struct
 		u.Path += "/result"
@@ -346,11 +481,8 @@ func (srv *Server) padPayoutTakerPost(w http.ResponseWriter, r *http.Request, au
 	}
 }
 
-func (srv *Server) padPayoutTakerResultGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	if !authpad.CanPayout() {
-		return NotFound()
-	}
-
+// payoutResult returns the name of the taker and their shifts with the paid out takes whose ids are in the "paid_out" url query.
+func (srv *Server) payoutResult(r *http.Request, authpad shiftpad.AuthPad) (string, []shiftpad.Shift, error) {
 	// ids from url query
 	var takeIDs = make(map[int]any)
 	for _, s := range r.URL.Query()["paid_out"] {
@@ -363,13 +495,13 @@ func (srv *Server) padPayoutTakerResultGet(w http.ResponseWriter, r *http.Reques
 	takerName := r.PathValue("taker")
 	shifts, err := srv.DB.GetTakesByTaker(authpad.Pad, takerName)
 	if err != nil {
-		return InternalServerError(err)
+		return "", nil, err
 	}
 	for i := range shifts {
 		// filter takes
 		n := 0
 		for _, take := range shifts[i].Takes {
-			if _, ok := takeIDs[take.ID]; ok {
+			if _, ok := takeIDs[take.ID]; ok && take.PaidOut {
 				shifts[i].Takes[n] = take
 				n++
 			}
@@ -377,10 +509,23 @@ func (srv *Server) padPayoutTakerResultGet(w http.ResponseWriter, r *http.Reques
 		shifts[i].Takes = shifts[i].Takes[:n]
 	}
 
-	// filter empty shifts so they don't disturb the table
+	// filter empty shifts so they don't disturb the table, and shifts which auth can't pay out
 	shifts = slices.DeleteFunc(shifts, func(shift shiftpad.Shift) bool {
-		return !shift.Paid && !shift.HasPayouts()
+		return (!shift.Paid && !shift.HasPayouts()) || !authpad.CanPayout(shift.Name)
 	})
+	return takerName, shifts, nil
+}
+
+func (srv *Server) padPayoutTakerResultGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanPayoutAnyShift() {
+		return NotFound()
+	}
+
+	takerName, shifts, err := srv.payoutResult(r, authpad)
+	if err != nil {
+		return InternalServerError(err)
+	}
+	payoutID, _ := strconv.Atoi(r.URL.Query().Get("payout"))
 
 	// group consecutive shifts by event (copied from above)
 	var events []shiftpad.Event
@@ -396,13 +541,11 @@ func (srv *Server) padPayoutTakerResultGet(w http.ResponseWriter, r *http.Reques
 		lastUID = shift.EventUID
 	}
 
-	// sum hours (as in html template)
-	var sumHours float64
-	for _, event := range events {
-		for _, shift := range event.Shifts {
-			for _ = range shift.Takes { // if someone has multiple takes of a shift, the hours are also added multiple times
-				sumHours += shift.Hours()
-			}
+	// sum hours and amounts (as in html template)
+	var total shiftpad.PayoutTotal
+	for _, shift := range shifts {
+		for _, take := range shift.Takes {
+			total.Add(authpad.Pad, shift, take)
 		}
 	}
 
@@ -416,7 +559,188 @@ func (srv *Server) padPayoutTakerResultGet(w http.ResponseWriter, r *http.Reques
 			Name:   takerName,
 			Events: events,
 		},
-		SumHours: sumHours,
+		PayoutID:      payoutID,
+		SumAmount:     total.Amount,
+		SumHours:      total.Hours,
+		SumSurcharges: total.Surcharges,
+	})
+	if err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+// payoutReceipt returns a printable receipt of the payout, like the payout result page.
+func (srv *Server) payoutReceipt(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, payout shiftpad.Payout) http.Handler {
+	if payout.Undone() {
+		return NotFound()
+	}
+
+	// collect the takes of the payout by iterating over GetTakesByTaker, takes which have been deleted in the meantime are missing
+	var takeIDs = make(map[int]any)
+	for _, take := range payout.Takes {
+		takeIDs[take.TakeID] = struct{}{}
+	}
+	shifts, err := srv.DB.GetTakesByTaker(authpad.Pad, payout.Taker)
+	if err != nil {
+		return InternalServerError(err)
+	}
+	for i := range shifts {
+		shifts[i].Takes = slices.DeleteFunc(shifts[i].Takes, func(take shiftpad.Take) bool {
+			_, ok := takeIDs[take.ID]
+			return !ok
+		})
+	}
+	shifts = slices.DeleteFunc(shifts, func(shift shiftpad.Shift) bool {
+		return len(shift.Takes) == 0
+	})
+
+	receipt := makeReceipt(html.MakeLang(r), authpad, payout, shifts)
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="payout-receipt-%s.pdf"`, payout.Created.In(authpad.Location).Format(time.DateOnly)))
+	if _, err := receipt.WriteTo(w); err != nil {
+		log.Printf("error writing pdf: %v", err) // too late for an error page
+	}
+	return nil
+}
+
+// dateRange reads the "from" and "to" dates from the url query. It defaults to the current month. Both dates are inclusive.
+func dateRange(r *http.Request, loc *time.Location) (time.Time, time.Time) {
+	now := time.Now().In(loc)
+	from, err := time.ParseInLocation(time.DateOnly, r.URL.Query().Get("from"), loc)
+	if err != nil {
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	}
+	to, err := time.ParseInLocation(time.DateOnly, r.URL.Query().Get("to"), loc)
+	if err != nil || to.Before(from) {
+		to = time.Date(from.Year(), from.Month()+1, 0, 0, 0, 0, 0, loc) // last day of month
+	}
+	return from, to
+}
+
+// payoutReport reads the date range from the url query (see dateRange) and returns the payout report of the shift names which auth can pay out.
+func (srv *Server) payoutReport(r *http.Request, authpad shiftpad.AuthPad) (shiftpad.PayoutReport, time.Time, time.Time, error) {
+	from, to := dateRange(r, authpad.Location)
+	sums, err := srv.DB.GetPayoutSums(authpad.Pad, from.Unix(), to.AddDate(0, 0, 1).Unix())
+	if err != nil {
+		return shiftpad.PayoutReport{}, from, to, err
+	}
+	sums = slices.DeleteFunc(sums, func(sum shiftpad.PayoutSum) bool {
+		return !authpad.CanPayout(sum.ShiftName)
+	})
+	return shiftpad.NewPayoutReport(authpad.Pad, sums), from, to, nil
+}
+
+func (srv *Server) padPayoutReportGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanPayoutAnyShift() {
+		return NotFound()
+	}
+
+	report, from, to, err := srv.payoutReport(r, authpad)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	err = html.PadPayoutReport.Execute(w, html.PadPayoutReportData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "payout",
+			Pad:        authpad,
+		},
+		From:   from,
+		To:     to,
+		Report: report,
+	})
+	if err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) padPayoutReportCSV(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanPayoutAnyShift() {
+		return NotFound()
+	}
+
+	report, from, to, err := srv.payoutReport(r, authpad)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	fmtFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 2, 64)
+	}
+	var records = [][]string{
+		{"taker", "shift", "rate", "paid out takes", "paid out hours", "paid out amount", "outstanding takes", "outstanding hours", "outstanding amount", "currency"},
+	}
+	for _, taker := range report.Takers {
+		for _, sum := range taker.Sums {
+			records = append(records, []string{
+				sum.Taker,
+				sum.ShiftName,
+				fmtFloat(sum.Rate),
+				strconv.Itoa(sum.PaidOutTakes),
+				fmtFloat(sum.PaidOutHours),
+				fmtFloat(sum.PaidOutAmount),
+				strconv.Itoa(sum.OutstandingTakes),
+				fmtFloat(sum.OutstandingHours),
+				fmtFloat(sum.OutstandingAmount),
+				authpad.Currency,
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="payout-report-%s-%s.csv"`, from.Format(time.DateOnly), to.Format(time.DateOnly)))
+	if err := csv.NewWriter(w).WriteAll(records); err != nil {
+		log.Printf("error writing csv: %v", err) // too late for an error page
+	}
+	return nil
+}
+
+func (srv *Server) padPayoutsGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanPayoutAnyShift() {
+		return NotFound()
+	}
+
+	payouts, err := srv.DB.GetPayouts(authpad.Pad)
+	if err != nil {
+		return InternalServerError(err)
+	}
+	payouts = slices.DeleteFunc(payouts, func(payout shiftpad.Payout) bool {
+		return !authpad.CanViewPayout(payout)
+	})
+
+	errs, _ := srv.sessionManager.Pop(r.Context(), "errs").([]string)
+
+	err = html.PadPayouts.Execute(w, html.PadPayoutsData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "payout",
+			Pad:        authpad,
+			Errors:     errs,
+		},
+		Payouts: payouts,
+	})
+	if err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) payoutUndoGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, payout shiftpad.Payout) http.Handler {
+	if !authpad.CanUndoPayout(payout) {
+		return NotFound()
+	}
+
+	err := html.PayoutUndo.Execute(w, html.PayoutUndoData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "payout",
+			Pad:        authpad,
+		},
+		Payout: payout,
 	})
 	if err != nil {
 		return InternalServerError(err)
@@ -424,17 +748,39 @@ func (srv *Server) padPayoutTakerResultGet(w http.ResponseWriter, r *http.Reques
 	return nil
 }
 
+func (srv *Server) payoutUndoPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, payout shiftpad.Payout) http.Handler {
+	if !authpad.CanUndoPayout(payout) {
+		return NotFound()
+	}
+
+	if err := srv.DB.UndoPayout(authpad.Pad, payout); errors.Is(err, shiftpad.ErrPayoutUndone) {
+		srv.sessionManager.Put(r.Context(), "errs", []string{err.Error()})
+		return http.RedirectHandler(authpad.Link()+"/payouts", http.StatusSeeOther)
+	} else if err != nil {
+		return InternalServerError(err)
+	}
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+
+	return http.RedirectHandler(authpad.Link()+"/payouts", http.StatusSeeOther)
+}
+
 func (srv *Server) padSettingsGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
 	if !authpad.Admin {
 		return NotFound()
 	}
+	return srv.padSettingsTemplate(w, r, authpad, "")
+}
 
+func (srv *Server) padSettingsTemplate(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, errMsg string) http.Handler {
 	err := html.PadSettings.Execute(w, html.PadSettingsData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
 			ActiveTab:  "settings",
 			Pad:        authpad,
 		},
+		Error:     errMsg,
 		Locations: shiftpad.Locations(authpad.Location.String()),
 	})
 	if err != nil {
@@ -469,6 +815,47 @@ func (srv *Server) padSettingsPost(w http.ResponseWriter, r *http.Request, authp
 	authpad.Location = loc
 	authpad.ShiftNames = shiftnames
 	authpad.ICalOverlay = icalURL
+	authpad.Currency = trim(r.PostFormValue("currency"), 8)
+	authpad.Rates = make(map[string]float64)
+	for _, shiftname := range shiftnames {
+		if rate := parseFloat(r.PostFormValue("rate-" + shiftname)); rate > 0 {
+			authpad.Rates[shiftname] = rate
+		}
+	}
+	authpad.Limits = shiftpad.Limits{
+		MinRest:  parseFloat(r.PostFormValue("min-rest")),
+		MaxDay:   parseFloat(r.PostFormValue("max-hours-day")),
+		MaxWeek:  parseFloat(r.PostFormValue("max-hours-week")),
+		MaxMonth: parseFloat(r.PostFormValue("max-hours-month")),
+	}
+	authpad.RejectOverlaps = r.PostFormValue("reject-overlaps") != ""
+	authpad.WaitlistApprove = r.PostFormValue("waitlist-approve") != ""
+
+	authpad.Surcharges = nil
+	for _, line := range split(trim(r.PostFormValue("surcharges"), 1024)) {
+		surcharge, err := shiftpad.ParseSurcharge(line)
+		if err != nil {
+			return srv.padSettingsTemplate(w, r, authpad, fmt.Sprintf("%s: %v", line, err))
+		}
+		authpad.Surcharges = append(authpad.Surcharges, surcharge)
+	}
+	authpad.Holidays = nil
+	for _, line := range split(trim(r.PostFormValue("holidays"), 4096)) {
+		if _, err := time.Parse(time.DateOnly, line); err != nil {
+			return srv.padSettingsTemplate(w, r, authpad, fmt.Sprintf("invalid holiday date: %s", line))
+		}
+		authpad.Holidays = append(authpad.Holidays, line)
+	}
+	slices.Sort(authpad.Holidays)
+	authpad.Holidays = slices.Compact(authpad.Holidays)
+	authpad.BreakRules = nil
+	for _, line := range split(trim(r.PostFormValue("break-rules"), 1024)) {
+		rule, err := shiftpad.ParseBreakRule(line)
+		if err != nil {
+			return srv.padSettingsTemplate(w, r, authpad, fmt.Sprintf("%s: %v", line, err))
+		}
+		authpad.BreakRules = append(authpad.BreakRules, rule)
+	}
 
 	if err := srv.DB.UpdatePad(authpad.Pad); err != nil {
 		return InternalServerError(err)
@@ -487,6 +874,13 @@ func (srv *Server) padShareGet(w http.ResponseWriter, r *http.Request, authpad s
 			ActiveTab:  "share",
 			Pad:        authpad,
 		},
+		Share: shiftpad.Share{
+			Auth: shiftpad.Auth{
+				CancelDeadline: authpad.CancelDeadline,
+				Expires:        authpad.Expires,
+				TakeDeadline:   authpad.TakeDeadline,
+			},
+		},
 	})
 	if err != nil {
 		return InternalServerError(err)
@@ -494,11 +888,12 @@ func (srv *Server) padShareGet(w http.ResponseWriter, r *http.Request, authpad s
 	return nil
 }
 
-func (srv *Server) padSharePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+// readAuthForm reads the share form and restricts the result to the auth of authpad.
+func readAuthForm(r *http.Request, authpad shiftpad.AuthPad) (shiftpad.Auth, error) {
 	expires := trim(r.PostFormValue("expires"), 10)
 	if expires != "" {
 		if _, err := time.Parse("2006-01-02", expires); err != nil {
-			return InternalServerError(err)
+			return shiftpad.Auth{}, err
 		}
 	}
 
@@ -509,16 +904,29 @@ func (srv *Server) padSharePost(w http.ResponseWriter, r *http.Request, authpad
 		}
 	}
 
-	shareAuth := authpad.Restrict(shiftpad.Auth{
+	cancelDeadline := trim(r.PostFormValue("cancel-deadline"), 64)
+	if cancelDeadline != "" {
+		if _, err := time.ParseDuration(cancelDeadline); err != nil {
+			if _, err := cronexpr.Parse(cancelDeadline); err != nil {
+				cancelDeadline = ""
+			}
+		}
+	}
+
+	return authpad.Restrict(shiftpad.Auth{
 		Admin:            r.PostFormValue("admin") != "",
 		Apply:            r.PostForm["apply"],
 		ApplyAll:         r.PostFormValue("apply-all") != "",
+		Cancel:           r.PostFormValue("cancel") != "",
+		CancelDeadline:   cancelDeadline,
 		Edit:             r.PostForm["edit"],
 		EditAll:          r.PostFormValue("edit-all") != "",
 		EditRetroAlways:  r.PostFormValue("edit-retro-always") != "",
 		Expires:          expires,
 		Note:             trim(r.PostFormValue("note"), 128),
+		Payout:           r.PostForm["payout"],
 		PayoutAll:        r.PostFormValue("payout-all") != "",
+		Record:           r.PostFormValue("record") != "",
 		Take:             r.PostForm["take"],
 		TakeAll:          r.PostFormValue("take-all") != "",
 		TakeDeadline:     takeDeadline,
@@ -526,16 +934,18 @@ func (srv *Server) padSharePost(w http.ResponseWriter, r *http.Request, authpad
 		TakerNameAll:     r.PostFormValue("taker-name-all") != "",
 		ViewTakerContact: r.PostFormValue("view-taker-contact") != "",
 		ViewTakerName:    r.PostFormValue("view-taker-name") != "",
-	})
+	}), nil
+}
 
-	secret := shiftpad.NewShareID()
-	if err := srv.DB.AddShare(*authpad.Pad, secret, shareAuth); err != nil {
+func (srv *Server) padSharePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	shareAuth, err := readAuthForm(r, authpad)
+	if err != nil {
 		return InternalServerError(err)
 	}
 
-	var scheme = "https"
-	if r.Host == "127.0.0.1" || strings.HasPrefix(r.Host, "127.0.0.1:") || strings.HasSuffix(r.Host, ".onion") {
-		scheme = "http"
+	secret := shiftpad.NewShareID()
+	if err := srv.DB.AddShare(*authpad.Pad, secret, shareAuth); err != nil {
+		return InternalServerError(err)
 	}
 
 	sharePad := shiftpad.AuthPad{
@@ -546,12 +956,12 @@ func (srv *Server) padSharePost(w http.ResponseWriter, r *http.Request, authpad
 		},
 	}
 
-	err := html.PadShareResult.Execute(w, html.PadShareResultData{
+	err = html.PadShareResult.Execute(w, html.PadShareResultData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
 			Pad:        authpad,
 		},
-		Host: scheme + "://" + r.Host,
+		Host: baseURL(r),
 		Link: sharePad.Link(),
 	})
 	if err != nil {
@@ -560,333 +970,1364 @@ func (srv *Server) padSharePost(w http.ResponseWriter, r *http.Request, authpad
 	return nil
 }
 
-func (srv *Server) padICal(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	cal := ical.NewCalendar()
-	cal.Props.SetText(ical.PropVersion, "2.0")
-	cal.Props.SetText(ical.PropProductID, "shiftpad")
+func (srv *Server) padSharesGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.Admin {
+		return NotFound()
+	}
 
-	from := time.Now().Add(-24 * time.Hour) // hardcoded, for shifts that have begun shortly before and have no end time
-	to := time.Now().Add(shiftpad.MaxFuture)
-	shifts, err := srv.DB.GetShifts(authpad.Pad, from.Unix(), to.Unix())
+	shares, err := srv.DB.GetShares(authpad.Pad)
 	if err != nil {
 		return InternalServerError(err)
 	}
+	slices.SortFunc(shares, func(a, b shiftpad.Share) int {
+		return strings.Compare(strings.ToLower(a.Note), strings.ToLower(b.Note))
+	})
 
-	for _, shift := range shifts {
-		uid := fmt.Sprintf("%d@%s", shift.ID, authpad.Pad.ID)
-
-		var summary strings.Builder
-		summary.WriteString(shift.Name)
-		if shift.Note != "" {
-			summary.WriteString("(")
-			summary.WriteString(shift.Note)
-			summary.WriteString(")")
-		}
-		if takers := shift.TakeViews(authpad.Auth); len(takers) > 0 {
-			summary.WriteString(":")
-			for _, taker := range takers {
-				summary.WriteString("\n")
-				summary.WriteString(taker.String())
-			}
-		}
-
-		event := ical.NewEvent()
-		event.Props.SetText(ical.PropUID, uid)
-		event.Props.SetText(ical.PropSummary, summary.String())
-		// use UTC ("Z") because go-ical can't export timezone details
-		event.Props.SetDateTime(ical.PropDateTimeStamp, shift.Modified.In(time.UTC))
-		event.Props.SetDateTime(ical.PropDateTimeStart, shift.Begin.In(time.UTC))
-		event.Props.SetDateTime(ical.PropDateTimeEnd, shift.End.In(time.UTC))
-		cal.Children = append(cal.Children, event.Component)
+	err = html.PadShares.Execute(w, html.PadSharesData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "shares",
+			Pad:        authpad,
+		},
+		Shares: shares,
+	})
+	if err != nil {
+		return InternalServerError(err)
 	}
+	return nil
+}
 
-	w.Header().Add("Content-Type", "text/calendar")
-
-	err = ical.NewEncoder(w).Encode(cal)
-	switch {
-	case err == nil:
-		return nil
-	case strings.Contains(err.Error(), "calendar is empty"): // go-ical and https://www.rfc-editor.org/rfc/rfc5545#section-3.6 require "one or more calendar components"
-		return nil
-	default:
+func (srv *Server) shareDeleteGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, share shiftpad.Share) http.Handler {
+	err := html.ShareDelete.Execute(w, html.ShareDeleteData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "shares",
+			Pad:        authpad,
+		},
+		Share: share,
+	})
+	if err != nil {
 		return InternalServerError(err)
 	}
+	return nil
 }
 
-func (srv *Server) padRedirectWeek(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	return http.RedirectHandler(authpad.Link()+"/week", http.StatusSeeOther)
-}
-
-func (srv *Server) padViewPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	yearStr, weekStr, _ := strings.Cut(r.PostFormValue("week"), "-W")
-	year, _ := strconv.Atoi(yearStr)
-	week, _ := strconv.Atoi(weekStr)
-	if year == 0 || week == 0 {
-		year, week = time.Now().ISOWeek()
+func (srv *Server) shareDeletePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, share shiftpad.Share) http.Handler {
+	if err := srv.DB.DeleteShare(authpad.Pad, share.Secret); err != nil {
+		return InternalServerError(err)
 	}
-	return http.RedirectHandler(authpad.Link()+fmt.Sprintf("/week/%d/%d", year, week), http.StatusSeeOther)
+	return http.RedirectHandler(authpad.Link()+"/shares", http.StatusSeeOther)
 }
 
-func (srv *Server) padViewDay(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	date, err := time.Parse("2006-01-02", r.PathValue("date"))
+func (srv *Server) shareEditGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, share shiftpad.Share) http.Handler {
+	err := html.ShareEdit.Execute(w, html.PadShareData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "shares",
+			Pad:        authpad,
+		},
+		Share: share,
+	})
 	if err != nil {
-		return NotFound()
+		return InternalServerError(err)
 	}
-	year, week := date.ISOWeek()
-	return http.RedirectHandler(authpad.Link()+fmt.Sprintf("/week/%d/%d#%s", year, week, date.Format("2006-01-02")), http.StatusSeeOther)
-}
-
-func (srv *Server) padViewCurrentMonthGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	now := time.Now()
-	return srv.padViewMonth(w, r, authpad, now.Year(), int(now.Month()))
+	return nil
 }
 
-func (srv *Server) padViewMonthGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	year, err := strconv.Atoi(r.PathValue("year"))
+func (srv *Server) shareEditPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, share shiftpad.Share) http.Handler {
+	shareAuth, err := readAuthForm(r, authpad)
 	if err != nil {
-		return NotFound()
+		return InternalServerError(err)
 	}
-	if year < 2022 || year > 2100 {
-		return NotFound()
+	share.Auth = shareAuth
+	if err := srv.DB.UpdateShare(authpad.Pad, share); err != nil {
+		return InternalServerError(err)
 	}
+	return http.RedirectHandler(authpad.Link()+"/shares", http.StatusSeeOther)
+}
 
-	month, err := strconv.Atoi(r.PathValue("month"))
-	if err != nil {
+func (srv *Server) padConflictsGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.Admin {
 		return NotFound()
 	}
-	if month < 1 {
-		month = 1
-	}
-	if month > 12 {
-		month = 12
-	}
-
-	return srv.padViewMonth(w, r, authpad, year, month)
-}
-
-func (srv *Server) padViewCurrentWeekGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	year, week := time.Now().ISOWeek()
-	return srv.padViewWeek(w, r, authpad, year, week)
-}
 
-func (srv *Server) padViewWeekGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	year, err := strconv.Atoi(r.PathValue("year"))
+	now := time.Now()
+	shifts, err := srv.DB.GetShifts(authpad.Pad, now.Unix(), now.Add(shiftpad.MaxFuture).Unix())
 	if err != nil {
-		return NotFound()
-	}
-	if year < 2022 || year > 2100 {
-		return NotFound()
+		return InternalServerError(err)
 	}
 
-	week, err := strconv.Atoi(r.PathValue("week"))
+	err = html.PadConflicts.Execute(w, html.PadConflictsData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "conflicts",
+			Pad:        authpad,
+		},
+		Conflicts: shiftpad.Conflicts(shifts),
+	})
 	if err != nil {
-		return NotFound()
-	}
-	if week < 1 {
-		week = 1
+		return InternalServerError(err)
 	}
-	if week > 53 {
-		week = 53
+	return nil
+}
+
+func (srv *Server) padHoursGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.Admin {
+		return NotFound()
 	}
 
-	return srv.padViewWeek(w, r, authpad, year, week)
-}
+	year, week, ok := parseISOWeek(r.URL.Query().Get("week"))
+	if !ok {
+		year, week = time.Now().In(authpad.Location).ISOWeek()
+	}
+	weekBegin := shiftpad.WeekBegin(year, week, authpad.Location)
+	year, week = weekBegin.ISOWeek() // normalize
+	month := time.Date(weekBegin.Year(), weekBegin.Month(), 1, 0, 0, 0, 0, authpad.Location)
 
-func (srv *Server) padViewMonth(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, year, monthNumber int) http.Handler {
-	month, err := shiftpad.GetMonth(srv, authpad.Pad, year, monthNumber, authpad.Location)
+	// the week can reach into the next month
+	from := month
+	to := month.AddDate(0, 1, 0)
+	if weekEnd := weekBegin.AddDate(0, 0, 7); weekEnd.After(to) {
+		to = weekEnd
+	}
+	shifts, err := srv.DB.GetShifts(authpad.Pad, from.Unix(), to.Unix())
 	if err != nil {
 		return InternalServerError(err)
 	}
 
-	earlier := month.Begin.AddDate(0, -1, 0)
-	later := month.Begin.AddDate(0, 1, 0)
-	upcoming := time.Now().AddDate(0, 1, 0)
-
-	errs, _ := srv.sessionManager.Pop(r.Context(), "errs").([]string)
-
-	if err := html.PadViewMonth.Execute(w, html.PadViewMonthData{
+	err = html.PadHours.Execute(w, html.PadHoursData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "hours",
 			Pad:        authpad,
-			Errors:     errs,
 		},
-		Month:         fmt.Sprintf("%04d-%02d", year, monthNumber),
-		Days:          month.Days,
-		EarlierYear:   earlier.Year(),
-		EarlierMonth:  int(earlier.Month()),
-		LaterYear:     later.Year(),
-		LaterMonth:    int(later.Month()),
-		UpcomingYear:  upcoming.Year(),
-		UpcomingMonth: int(upcoming.Month()),
-	}); err != nil {
+		Hours:   shiftpad.SummarizeHours(shifts, weekBegin),
+		ISOWeek: fmt.Sprintf("%04d-W%02d", year, week),
+		Month:   month,
+	})
+	if err != nil {
 		return InternalServerError(err)
 	}
 	return nil
 }
 
-func (srv *Server) padViewWeek(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, year, weekNumber int) http.Handler {
-	week, err := shiftpad.GetWeek(srv, authpad.Pad, year, weekNumber, authpad.Location)
-	if err != nil {
-		return InternalServerError(err)
+// padMyShiftsGet lists the shifts which have been taken by or applied for by the taker names of the share.
+func (srv *Server) padMyShiftsGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if len(authpad.TakerName) == 0 {
+		return NotFound()
 	}
 
-	earlierYear, earlierWeek := week.Begin.AddDate(0, 0, -7).ISOWeek()
-	laterYear, laterWeek := week.Begin.AddDate(0, 0, 7).ISOWeek()
-	upcomingYear, upcomingWeek := time.Now().AddDate(0, 0, 7).ISOWeek()
+	var shifts []shiftpad.Shift
+	for _, name := range authpad.TakerName {
+		taken, err := srv.DB.GetTakesByTaker(authpad.Pad, name)
+		if err != nil {
+			return InternalServerError(err)
+		}
+		for _, shift := range taken {
+			// merge takes of different taker names in the same shift
+			if i := slices.IndexFunc(shifts, func(s shiftpad.Shift) bool { return s.ID == shift.ID }); i >= 0 {
+				shifts[i].Takes = append(shifts[i].Takes, shift.Takes...)
+			} else {
+				shifts = append(shifts, shift)
+			}
+		}
+	}
+	slices.SortFunc(shifts, func(a, b shiftpad.Shift) int {
+		return a.Begin.Compare(b.Begin)
+	})
 
-	errs, _ := srv.sessionManager.Pop(r.Context(), "errs").([]string)
+	var upcoming, past []shiftpad.Shift
+	for _, shift := range shifts {
+		if shift.Over() {
+			past = append(past, shift)
+		} else {
+			upcoming = append(upcoming, shift)
+		}
+	}
+	slices.Reverse(past)
 
-	if err := html.PadViewWeek.Execute(w, html.PadViewWeekData{
+	err := html.PadMyShifts.Execute(w, html.PadMyShiftsData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "my-shifts",
 			Pad:        authpad,
-			Errors:     errs,
 		},
-		ISOWeek:      fmt.Sprintf("%04d-W%02d", year, weekNumber),
-		Days:         week.Days,
-		EarlierYear:  earlierYear,
-		EarlierWeek:  earlierWeek,
-		LaterYear:    laterYear,
-		LaterWeek:    laterWeek,
-		UpcomingYear: upcomingYear,
-		UpcomingWeek: upcomingWeek,
-	}); err != nil {
+		Upcoming: shiftpad.GroupByMonth(upcoming, authpad.Location),
+		Past:     shiftpad.GroupByMonth(past, authpad.Location),
+	})
+	if err != nil {
 		return InternalServerError(err)
 	}
 	return nil
 }
 
-func (srv *Server) shiftAddGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+func (srv *Server) padAttendanceGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
 	if !authpad.CanEditAnyShift() {
 		return NotFound()
 	}
 
-	return srv.shiftAddTemplate(w, r, authpad, "")
-}
-
-func (srv *Server) shiftAddTemplate(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, errMsg string) http.Handler {
-	date, err := time.Parse("2006-01-02", r.PathValue("date"))
+	from, to := dateRange(r, authpad.Location)
+	shifts, err := srv.DB.GetShifts(authpad.Pad, from.Unix(), to.AddDate(0, 0, 1).Unix())
 	if err != nil {
-		return NotFound()
+		return InternalServerError(err)
 	}
 
-	day, err := shiftpad.GetDay(srv, authpad.Pad, date, authpad.Location)
+	err = html.PadAttendance.Execute(w, html.PadAttendanceData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			ActiveTab:  "attendance",
+			Pad:        authpad,
+		},
+		From:        from,
+		To:          to,
+		Reliability: shiftpad.SummarizeAttendance(shifts, authpad.CanEdit),
+	})
 	if err != nil {
 		return InternalServerError(err)
 	}
+	return nil
+}
 
-	eventUID := r.URL.Query().Get("event")
-	var ok = false
-	for _, event := range day.Events {
-		if event.UID == eventUID {
-			ok = true
-			break
+// checkTaker checks whether the taker name may take shift in addition to their other shifts.
+// Violated limits are returned as errs. Overlapping shifts are returned as errs if the pad rejects overlaps, else as warns.
+func (srv *Server) checkTaker(authpad shiftpad.AuthPad, shift shiftpad.Shift, name string) (errs, warns []string, err error) {
+	others, err := srv.DB.GetTakesByTaker(authpad.Pad, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, other := range shiftpad.Overlapping(shift, name, others) {
+		msg := fmt.Sprintf("%s has also taken %s at %s", name, other, other.Begin.Format("2006-01-02 15:04"))
+		if authpad.RejectOverlaps {
+			errs = append(errs, msg)
+		} else {
+			warns = append(warns, msg)
 		}
 	}
-	if !ok {
-		eventUID = ""
+	errs = append(errs, authpad.Limits.Check(shift, name, others)...)
+	return errs, warns, nil
+}
+
+// checkTakes runs checkTaker for the takers of shift which have changed compared to original, see shiftpad.ChangedTakers.
+func (srv *Server) checkTakes(authpad shiftpad.AuthPad, original, shift shiftpad.Shift) (errs, warns []string, err error) {
+	for _, name := range shiftpad.ChangedTakers(original, shift) {
+		takeErrs, takeWarns, err := srv.checkTaker(authpad, shift, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		errs = append(errs, takeErrs...)
+		warns = append(warns, takeWarns...)
 	}
+	return errs, warns, nil
+}
 
-	var minDate = time.Now()
-	if authpad.EditRetroAlways {
-		minDate = time.Date(2000, time.January, 1, 0, 0, 0, 0, authpad.Location)
+const maxICalRange = 3 * 366 * 24 * time.Hour
+
+// padICal exports the shifts of the pad. The export can be restricted with these url query parameters:
+//
+//   - name: shift name, can be repeated
+//   - open: only shifts which are not fully taken
+//   - my: only shifts taken by or applied for by the taker names of the share
+//   - from, to: date range (yyyy-mm-dd, inclusive), defaults to yesterday until MaxFuture, at most maxICalRange
+func (srv *Server) padICal(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	query := r.URL.Query()
+	filter := shiftpad.ShiftFilter{
+		Names: query["name"],
+		Open:  query.Get("open") != "",
+	}
+	if query.Get("my") != "" {
+		if len(authpad.TakerName) == 0 {
+			return NotFound()
+		}
+		filter.TakerNames = authpad.TakerName
 	}
 
-	if err := html.ShiftCreate.Execute(w, html.ShiftCreateData{
-		PadData: html.PadData{
-			LayoutData: html.MakeLayoutData(r),
-			Pad:        authpad,
-		},
-		Day:      day,
-		EventUID: eventUID,
-		MaxDate:  time.Now().Add(shiftpad.MaxFuture).Format("2006-01-02"),
-		MinDate:  minDate.Format("2006-01-02"),
-		Error:    errMsg,
-	}); err != nil {
+	from := time.Now().Add(-24 * time.Hour) // hardcoded, for shifts that have begun shortly before and have no end time
+	to := time.Now().Add(shiftpad.MaxFuture)
+	if date, err := time.ParseInLocation(time.DateOnly, query.Get("from"), authpad.Location); err == nil {
+		from = date
+	}
+	if date, err := time.ParseInLocation(time.DateOnly, query.Get("to"), authpad.Location); err == nil {
+		to = date.AddDate(0, 0, 1)
+	}
+	if !to.After(from) || to.Sub(from) > maxICalRange {
+		return NotFound()
+	}
+	cal := newCalendar(authpad.Location, from, to)
+
+	shifts, err := srv.DB.GetShifts(authpad.Pad, from.Unix(), to.Unix())
+	if err != nil {
 		return InternalServerError(err)
 	}
-	return nil
+
+	base := baseURL(r)
+	eventSummaries := srv.eventSummaries(authpad)
+	for _, shift := range shifts {
+		if !filter.Match(shift) {
+			continue
+		}
+		event := shiftEvent(authpad, shift, shift.TakeViews(authpad.Auth), base, eventSummaries[shift.EventUID])
+		cal.Children = append(cal.Children, event.Component)
+	}
+
+	return writeCalendar(w, cal)
 }
 
-func (srv *Server) shiftAddPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
-	if !authpad.CanEditAnyShift() {
+// padMyShiftsICal exports the shifts which have been taken by or applied for by the taker names of the share, or by the taker name in the url query if the share can view it.
+// The event status is confirmed if a take has been approved, else tentative.
+func (srv *Server) padMyShiftsICal(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	names := authpad.TakerName
+	if taker := r.URL.Query().Get("taker"); taker != "" {
+		names = []string{taker}
+	}
+	if len(names) == 0 {
 		return NotFound()
 	}
 
-	eventUID := trim(r.PostFormValue("event-uid"), 128) // could also be taken from url query
-
-	quantites := r.PostForm["quantity"]
-	begins := r.PostForm["begin"]
-	ends := r.PostForm["end"]
-	names := r.PostForm["name"]
-	notes := r.PostForm["note"]
-	paids := r.PostForm["paid"]
+	from := time.Now().Add(-24 * time.Hour) // like padICal
+	to := time.Now().Add(shiftpad.MaxFuture)
+	cal := newCalendar(authpad.Location, from, to)
 
-	var errs []string
-	for i := 0; i < min(len(quantites), len(begins), len(ends), len(names), len(notes)); i++ {
-		quantity, err := strconv.Atoi(quantites[i])
+	var shifts []shiftpad.Shift
+	for _, name := range names {
+		taken, err := srv.DB.GetTakesByTaker(authpad.Pad, name)
 		if err != nil {
-			continue
-		}
-		if quantity < 1 {
-			quantity = 1
+			return InternalServerError(err)
 		}
-		if quantity > 64 {
-			quantity = 64
+		for _, shift := range taken {
+			if shift.Begin.Before(from) {
+				continue
+			}
+			// merge takes of different taker names in the same shift
+			if i := slices.IndexFunc(shifts, func(s shiftpad.Shift) bool { return s.ID == shift.ID }); i >= 0 {
+				shifts[i].Takes = append(shifts[i].Takes, shift.Takes...)
+			} else {
+				shifts = append(shifts, shift)
+			}
 		}
+	}
+	slices.SortFunc(shifts, func(a, b shiftpad.Shift) int {
+		return a.Begin.Compare(b.Begin)
+	})
 
-		begin, err := time.ParseInLocation("2006-01-02T15:04", begins[i], authpad.Location)
-		if err != nil {
-			continue
-		}
-		end, err := time.ParseInLocation("2006-01-02T15:04", ends[i], authpad.Location)
-		if err != nil {
-			continue
-		}
-		if err := shiftpad.CheckBeginEnd(begin, end, authpad.EditRetroAlways, shiftpad.MaxFuture); err != nil {
-			errs = append(errs, fmt.Sprintf("adding row %d: %v", i+1, err))
+	base := baseURL(r)
+	eventSummaries := srv.eventSummaries(authpad)
+	for _, shift := range shifts {
+		// TakeViews hides names which the share can't view, so they are not matched
+		takers := slices.DeleteFunc(shift.TakeViews(authpad.Auth), func(take shiftpad.Take) bool {
+			return take.Rejected || !slices.Contains(names, take.Name)
+		})
+		if len(takers) == 0 {
 			continue
 		}
 
-		name := trim(names[i], 64)
-		if name == "" {
-			continue
+		event := shiftEvent(authpad, shift, takers, base, eventSummaries[shift.EventUID])
+		if slices.ContainsFunc(takers, func(take shiftpad.Take) bool { return take.Approved }) {
+			event.SetStatus(ical.EventConfirmed)
+		} else {
+			event.SetStatus(ical.EventTentative)
 		}
-		note := trim(notes[i], 64)
-		paid := slices.Contains(paids, strconv.Itoa(i)) // Checkbox form input is sparse, so we can't use its indices. Instead we have submitted the form row indices.
+		cal.Children = append(cal.Children, event.Component)
+	}
 
-		shift := shiftpad.Shift{
-			Name:     name,
-			Note:     note,
-			Paid:     paid,
-			Modified: time.Now().In(authpad.Location),
-			EventUID: eventUID,
-			Quantity: quantity,
-			Begin:    begin,
-			End:      end,
+	return writeCalendar(w, cal)
+}
+
+// newCalendar returns a calendar with a VTIMEZONE component of loc, which covers events between from and to.
+func newCalendar(loc *time.Location, from, to time.Time) *ical.Calendar {
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "shiftpad")
+	if loc != time.UTC { // UTC times are written with "Z" and have no TZID
+		cal.Children = append(cal.Children, shiftpad.VTimezone(loc, from, to))
+	}
+	return cal
+}
+
+// shiftEvent returns an iCalendar event of the shift. The takers are added as attendees. The url of the day view is prefixed with base.
+// The status is tentative if the shift is not fully taken.
+func shiftEvent(authpad shiftpad.AuthPad, shift shiftpad.Shift, takers []shiftpad.Take, base, eventSummary string) *ical.Event {
+	uid := fmt.Sprintf("%d@%s", shift.ID, authpad.Pad.ID)
+
+	var description []string
+	if shift.Note != "" {
+		description = append(description, shift.Note)
+	}
+	if eventSummary != "" {
+		description = append(description, eventSummary)
+	}
+
+	event := ical.NewEvent()
+	event.Props.SetText(ical.PropUID, uid)
+	event.Props.SetText(ical.PropSummary, shift.Name)
+	if len(description) > 0 {
+		event.Props.SetText(ical.PropDescription, strings.Join(description, "\n"))
+	}
+	if u, err := url.Parse(base + linkDay(authpad, shift.Begin)); err == nil {
+		event.Props.SetURI(ical.PropURL, u)
+	}
+	event.Props.SetDateTime(ical.PropDateTimeStamp, shift.Modified.In(time.UTC)) // must be UTC
+	if !shift.Modified.IsZero() {
+		event.Props.SetDateTime(ical.PropLastModified, shift.Modified.In(time.UTC))
+	}
+	// TZID refers to the VTIMEZONE component, see newCalendar
+	event.Props.SetDateTime(ical.PropDateTimeStart, shift.Begin.In(authpad.Location))
+	event.Props.SetDateTime(ical.PropDateTimeEnd, shift.End.In(authpad.Location))
+	if shift.FullyTaken() {
+		event.SetStatus(ical.EventConfirmed)
+	} else {
+		event.SetStatus(ical.EventTentative)
+	}
+
+	for _, taker := range takers {
+		if taker.ID == 0 {
+			continue // summarized anonymous takers
+		}
+		attendee := ical.NewProp(ical.PropAttendee)
+		attendee.Params.Set(ical.ParamCommonName, taker.Name)
+		switch {
+		case taker.Rejected:
+			attendee.Params.Set(ical.ParamParticipationStatus, "DECLINED")
+		case taker.Approved:
+			attendee.Params.Set(ical.ParamParticipationStatus, "ACCEPTED")
+		default:
+			attendee.Params.Set(ical.ParamParticipationStatus, "TENTATIVE")
 		}
+		if strings.Contains(taker.Contact, "@") && !strings.ContainsAny(taker.Contact, " :") {
+			attendee.Value = "mailto:" + taker.Contact
+		} else {
+			attendee.Value = "urn:uuid:" + takeUUID(authpad.Pad, taker) // attendees must have a calendar address, and clients merge attendees with the same one
+		}
+		event.Props.Add(attendee)
+	}
+	return event
+}
 
-		if !authpad.CanEditShift(shift) {
-			errs = append(errs, fmt.Sprintf("adding row %d: unauthorized: %v", i+1, err))
-			continue
+// takeUUID returns a name-based UUID of the take, which is used as calendar address of takers without an email address.
+func takeUUID(pad *shiftpad.Pad, take shiftpad.Take) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s/%d", pad.ID, take.ID)))
+	sum[6] = sum[6]&0x0f | 0x50 // version 5
+	sum[8] = sum[8]&0x3f | 0x80 // variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// eventSummaries returns the summaries of the events of the iCalendar overlay of the pad, by uid.
+func (srv *Server) eventSummaries(authpad shiftpad.AuthPad) map[string]string {
+	var summaries = make(map[string]string)
+	icalEvents, _ := srv.GetICalFeedCache(authpad.ICalOverlay).Get(authpad.Location)
+	for _, icalEvent := range icalEvents {
+		summaries[icalEvent.UID] = icalEvent.Summary
+	}
+	return summaries
+}
+
+func writeCalendar(w http.ResponseWriter, cal *ical.Calendar) http.Handler {
+	w.Header().Add("Content-Type", "text/calendar")
+
+	err := ical.NewEncoder(w).Encode(cal)
+	switch {
+	case err == nil:
+		return nil
+	case strings.Contains(err.Error(), "calendar is empty"): // go-ical and https://www.rfc-editor.org/rfc/rfc5545#section-3.6 require "one or more calendar components"
+		return nil
+	default:
+		return InternalServerError(err)
+	}
+}
+
+func (srv *Server) padRedirectWeek(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	return http.RedirectHandler(authpad.Link()+"/week", http.StatusSeeOther)
+}
+
+func (srv *Server) padViewPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	year, week, ok := parseISOWeek(r.PostFormValue("week"))
+	if !ok {
+		year, week = time.Now().ISOWeek()
+	}
+	return http.RedirectHandler(authpad.Link()+fmt.Sprintf("/week/%d/%d", year, week), http.StatusSeeOther)
+}
+
+// parseISOWeek parses the value of a week input like "2025-W07".
+func parseISOWeek(s string) (int, int, bool) {
+	yearStr, weekStr, _ := strings.Cut(s, "-W")
+	year, _ := strconv.Atoi(yearStr)
+	week, _ := strconv.Atoi(weekStr)
+	if year == 0 || week == 0 {
+		return 0, 0, false
+	}
+	return year, week, true
+}
+
+// weekPathValues reads the year and week path values. The week is clamped to [1, 53].
+func weekPathValues(r *http.Request) (int, int, bool) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		return 0, 0, false
+	}
+	if year < 2022 || year > 2100 {
+		return 0, 0, false
+	}
+
+	week, err := strconv.Atoi(r.PathValue("week"))
+	if err != nil {
+		return 0, 0, false
+	}
+	if week < 1 {
+		week = 1
+	}
+	if week > 53 {
+		week = 53
+	}
+	return year, week, true
+}
+
+func (srv *Server) padViewDay(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	date, err := time.Parse("2006-01-02", r.PathValue("date"))
+	if err != nil {
+		return NotFound()
+	}
+	year, week := date.ISOWeek()
+	return http.RedirectHandler(authpad.Link()+fmt.Sprintf("/week/%d/%d#%s", year, week, date.Format("2006-01-02")), http.StatusSeeOther)
+}
+
+func (srv *Server) padViewCurrentMonthGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	now := time.Now()
+	return srv.padViewMonth(w, r, authpad, now.Year(), int(now.Month()))
+}
+
+func (srv *Server) padViewMonthGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		return NotFound()
+	}
+	if year < 2022 || year > 2100 {
+		return NotFound()
+	}
+
+	month, err := strconv.Atoi(r.PathValue("month"))
+	if err != nil {
+		return NotFound()
+	}
+	if month < 1 {
+		month = 1
+	}
+	if month > 12 {
+		month = 12
+	}
+
+	return srv.padViewMonth(w, r, authpad, year, month)
+}
+
+func (srv *Server) padViewCurrentWeekGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	year, week := time.Now().ISOWeek()
+	return srv.padViewWeek(w, r, authpad, year, week)
+}
+
+func (srv *Server) padViewWeekGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	year, week, ok := weekPathValues(r)
+	if !ok {
+		return NotFound()
+	}
+	return srv.padViewWeek(w, r, authpad, year, week)
+}
+
+func (srv *Server) padViewMonth(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, year, monthNumber int) http.Handler {
+	month, err := shiftpad.GetMonth(srv, authpad.Pad, year, monthNumber, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	earlier := month.Begin.AddDate(0, -1, 0)
+	later := month.Begin.AddDate(0, 1, 0)
+	upcoming := time.Now().AddDate(0, 1, 0)
+
+	errs, _ := srv.sessionManager.Pop(r.Context(), "errs").([]string)
+	warns, _ := srv.sessionManager.Pop(r.Context(), "warns").([]string)
+
+	if err := html.PadViewMonth.Execute(w, html.PadViewMonthData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+			Errors:     errs,
+			Warnings:   warns,
+		},
+		Month:         fmt.Sprintf("%04d-%02d", year, monthNumber),
+		Days:          month.Days,
+		EarlierYear:   earlier.Year(),
+		EarlierMonth:  int(earlier.Month()),
+		LaterYear:     later.Year(),
+		LaterMonth:    int(later.Month()),
+		UpcomingYear:  upcoming.Year(),
+		UpcomingMonth: int(upcoming.Month()),
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) padViewWeek(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, year, weekNumber int) http.Handler {
+	week, err := shiftpad.GetWeek(srv, authpad.Pad, year, weekNumber, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	earlierYear, earlierWeek := week.Begin.AddDate(0, 0, -7).ISOWeek()
+	laterYear, laterWeek := week.Begin.AddDate(0, 0, 7).ISOWeek()
+	upcomingYear, upcomingWeek := time.Now().AddDate(0, 0, 7).ISOWeek()
+
+	errs, _ := srv.sessionManager.Pop(r.Context(), "errs").([]string)
+	warns, _ := srv.sessionManager.Pop(r.Context(), "warns").([]string)
+
+	if err := html.PadViewWeek.Execute(w, html.PadViewWeekData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+			Errors:     errs,
+			Warnings:   warns,
+		},
+		ISOWeek:      fmt.Sprintf("%04d-W%02d", year, weekNumber),
+		Year:         year,
+		Week:         weekNumber,
+		Days:         week.Days,
+		EarlierYear:  earlierYear,
+		EarlierWeek:  earlierWeek,
+		LaterYear:    laterYear,
+		LaterWeek:    laterWeek,
+		UpcomingYear: upcomingYear,
+		UpcomingWeek: upcomingWeek,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) shiftAddGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanEditAnyShift() {
+		return NotFound()
+	}
+
+	return srv.shiftAddTemplate(w, r, authpad, "")
+}
+
+func (srv *Server) shiftAddTemplate(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, errMsg string) http.Handler {
+	date, err := time.Parse("2006-01-02", r.PathValue("date"))
+	if err != nil {
+		return NotFound()
+	}
+
+	day, err := shiftpad.GetDay(srv, authpad.Pad, date, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	eventUID := r.URL.Query().Get("event")
+	var ok = false
+	for _, event := range day.Events {
+		if event.UID == eventUID {
+			ok = true
+			break
 		}
+	}
+	if !ok {
+		eventUID = ""
+	}
+
+	var minDate = time.Now()
+	if authpad.EditRetroAlways {
+		minDate = time.Date(2000, time.January, 1, 0, 0, 0, 0, authpad.Location)
+	}
+
+	if err := html.ShiftCreate.Execute(w, html.ShiftCreateData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Day:      day,
+		EventUID: eventUID,
+		MaxDate:  time.Now().Add(shiftpad.MaxFuture).Format("2006-01-02"),
+		MinDate:  minDate.Format("2006-01-02"),
+		Error:    errMsg,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) shiftAddPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanEditAnyShift() {
+		return NotFound()
+	}
+
+	eventUID := trim(r.PostFormValue("event-uid"), 128) // could also be taken from url query
+	rrule := trim(r.PostFormValue("rrule"), 256)
+	if eventUID != "" {
+		rrule = "" // events don't recur
+	}
+
+	quantites := r.PostForm["quantity"]
+	begins := r.PostForm["begin"]
+	ends := r.PostForm["end"]
+	names := r.PostForm["name"]
+	notes := r.PostForm["note"]
+	paids := r.PostForm["paid"]
+	breaks := r.PostForm["break"]
+
+	var errs []string
+	for i := 0; i < min(len(quantites), len(begins), len(ends), len(names), len(notes)); i++ {
+		quantity, err := strconv.Atoi(quantites[i])
+		if err != nil {
+			continue
+		}
+		if quantity < 1 {
+			quantity = 1
+		}
+		if quantity > 64 {
+			quantity = 64
+		}
+
+		begin, err := time.ParseInLocation("2006-01-02T15:04", begins[i], authpad.Location)
+		if err != nil {
+			continue
+		}
+		end, err := time.ParseInLocation("2006-01-02T15:04", ends[i], authpad.Location)
+		if err != nil {
+			continue
+		}
+		if err := shiftpad.CheckBeginEnd(begin, end, authpad.EditRetroAlways, shiftpad.MaxFuture); err != nil {
+			errs = append(errs, fmt.Sprintf("adding row %d: %v", i+1, err))
+			continue
+		}
+
+		name := trim(names[i], 64)
+		if name == "" {
+			continue
+		}
+		note := trim(notes[i], 64)
+		paid := slices.Contains(paids, strconv.Itoa(i)) // Checkbox form input is sparse, so we can't use its indices. Instead we have submitted the form row indices.
+		var brk time.Duration
+		if i < len(breaks) {
+			brk = time.Duration(parseFloat(breaks[i])) * time.Minute
+		}
+		brk = max(brk, authpad.MinBreak(end.Sub(begin)))
+		if brk >= end.Sub(begin) {
+			errs = append(errs, fmt.Sprintf("adding row %d: break must be shorter than the shift", i+1))
+			continue
+		}
+
+		shift := shiftpad.Shift{
+			Name:     name,
+			Note:     note,
+			Paid:     paid,
+			Modified: time.Now().In(authpad.Location),
+			EventUID: eventUID,
+			Quantity: quantity,
+			Begin:    begin,
+			End:      end,
+			Break:    brk,
+		}
+
+		if rrule == "" {
+			if !authpad.CanEditShift(shift) {
+				errs = append(errs, fmt.Sprintf("adding row %d: unauthorized: %v", i+1, err))
+				continue
+			}
+
+			if err := srv.DB.AddShift(authpad.Pad, shift); err != nil {
+				return InternalServerError(err)
+			}
+			continue
+		}
+
+		occurrences, err := shiftpad.Occurrences(rrule, begin, time.Now().Add(shiftpad.MaxFuture))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("adding row %d: recurrence rule: %v", i+1, err))
+			continue
+		}
+		var series []shiftpad.Shift
+		for _, occurrence := range occurrences {
+			shift.Begin, shift.End = shiftpad.Reschedule(occurrence, begin, begin, end)
+			if !authpad.CanEditShift(shift) {
+				errs = append(errs, fmt.Sprintf("adding row %d on %s: unauthorized", i+1, shift.Begin.Format(time.DateOnly)))
+				continue
+			}
+			series = append(series, shift)
+		}
+		if err := srv.DB.AddSeries(authpad.Pad, series); err != nil {
+			return InternalServerError(err)
+		}
+	}
+	srv.sessionManager.Put(r.Context(), "errs", errs)
+
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+
+	redirectDate := r.PathValue("date") // alternative: min shift or event begin time
+	return http.RedirectHandler(authpad.Link()+fmt.Sprintf("/day/%s", redirectDate), http.StatusSeeOther)
+}
+
+func (srv *Server) shiftCopyDayGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanEditAnyShift() {
+		return NotFound()
+	}
+
+	begin, err := time.ParseInLocation("2006-01-02", r.PathValue("date"), authpad.Location)
+	if err != nil {
+		return NotFound()
+	}
+	target := begin.AddDate(0, 0, 7).Format("2006-01-02")
+	return srv.shiftCopyTemplate(w, r, authpad, begin, begin.AddDate(0, 0, 1), false, target)
+}
+
+func (srv *Server) shiftCopyDayPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanEditAnyShift() {
+		return NotFound()
+	}
+
+	begin, err := time.ParseInLocation("2006-01-02", r.PathValue("date"), authpad.Location)
+	if err != nil {
+		return NotFound()
+	}
+	target, err := time.ParseInLocation("2006-01-02", r.PostFormValue("target"), authpad.Location)
+	if err != nil {
+		srv.sessionManager.Put(r.Context(), "errs", []string{"copying shifts: invalid target day"})
+		return http.RedirectHandler(linkDay(authpad, begin), http.StatusSeeOther)
+	}
+	return srv.shiftCopy(r, authpad, begin, begin.AddDate(0, 0, 1), target)
+}
+
+func (srv *Server) shiftCopyWeekGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanEditAnyShift() {
+		return NotFound()
+	}
+
+	year, week, ok := weekPathValues(r)
+	if !ok {
+		return NotFound()
+	}
+	begin := shiftpad.WeekBegin(year, week, authpad.Location)
+	targetYear, targetWeek := begin.AddDate(0, 0, 7).ISOWeek()
+	return srv.shiftCopyTemplate(w, r, authpad, begin, begin.AddDate(0, 0, 7), true, fmt.Sprintf("%04d-W%02d", targetYear, targetWeek))
+}
+
+func (srv *Server) shiftCopyWeekPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad) http.Handler {
+	if !authpad.CanEditAnyShift() {
+		return NotFound()
+	}
+
+	year, week, ok := weekPathValues(r)
+	if !ok {
+		return NotFound()
+	}
+	begin := shiftpad.WeekBegin(year, week, authpad.Location)
+	targetYear, targetWeek, ok := parseISOWeek(r.PostFormValue("target"))
+	if !ok {
+		srv.sessionManager.Put(r.Context(), "errs", []string{"copying shifts: invalid target week"})
+		return http.RedirectHandler(linkDay(authpad, begin), http.StatusSeeOther)
+	}
+	return srv.shiftCopy(r, authpad, begin, begin.AddDate(0, 0, 7), shiftpad.WeekBegin(targetYear, targetWeek, authpad.Location))
+}
+
+// copyShiftsSource returns the shifts which begin in [begin, end).
+func (srv *Server) copyShiftsSource(authpad shiftpad.AuthPad, begin, end time.Time) ([]shiftpad.Shift, error) {
+	shifts, err := srv.DB.GetShifts(authpad.Pad, begin.Unix(), end.Unix())
+	if err != nil {
+		return nil, err
+	}
+	shifts = slices.DeleteFunc(shifts, func(shift shiftpad.Shift) bool {
+		return shift.Begin.Before(begin) || !shift.Begin.Before(end)
+	})
+	slices.SortFunc(shifts, func(a, b shiftpad.Shift) int {
+		return a.Begin.Compare(b.Begin)
+	})
+	return shifts, nil
+}
+
+func (srv *Server) shiftCopyTemplate(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, begin, end time.Time, week bool, target string) http.Handler {
+	shifts, err := srv.copyShiftsSource(authpad, begin, end)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	var hasEvents bool
+	for _, shift := range shifts {
+		if shift.EventUID != "" {
+			hasEvents = true
+		}
+	}
+
+	if err := html.ShiftCopy.Execute(w, html.ShiftCopyData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Begin:     begin,
+		End:       end,
+		Week:      week,
+		Shifts:    shifts,
+		HasEvents: hasEvents,
+		Target:    target,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+// shiftCopy copies the shifts which begin in [begin, end) to target, which is the new begin of the timespan.
+// Takes are not copied. Shifts which must not be created by authpad are skipped and reported.
+func (srv *Server) shiftCopy(r *http.Request, authpad shiftpad.AuthPad, begin, end, target time.Time) http.Handler {
+	if target.Equal(begin) {
+		srv.sessionManager.Put(r.Context(), "errs", []string{"copying shifts: target equals source"})
+		return http.RedirectHandler(linkDay(authpad, begin), http.StatusSeeOther)
+	}
+
+	shifts, err := srv.copyShiftsSource(authpad, begin, end)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	keepEvents := r.PostFormValue("events") != ""
+
+	var copies []shiftpad.Shift
+	var errs []string
+	for _, shift := range shifts {
+		copied := shift.CopyTo(begin, target)
+		copied.Modified = time.Now().In(authpad.Location)
+		if !keepEvents {
+			copied.EventUID = ""
+		}
+		if err := shiftpad.CheckBeginEnd(copied.Begin, copied.End, authpad.EditRetroAlways, shiftpad.MaxFuture); err != nil {
+			errs = append(errs, fmt.Sprintf("copying %s on %s: %v", shift, shift.Begin.Format("2006-01-02 15:04"), err))
+			continue
+		}
+		if !authpad.CanEditShift(copied) {
+			errs = append(errs, fmt.Sprintf("copying %s on %s: unauthorized", shift, shift.Begin.Format("2006-01-02 15:04")))
+			continue
+		}
+		copies = append(copies, copied)
+	}
+
+	if err := srv.DB.AddShifts(authpad.Pad, copies); err != nil {
+		return InternalServerError(err)
+	}
+	srv.sessionManager.Put(r.Context(), "errs", errs)
+
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+
+	return http.RedirectHandler(linkDay(authpad, target), http.StatusSeeOther)
+}
+
+func (srv *Server) shiftDeleteGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanEditShift(*shift) {
+		return NotFound()
+	}
+
+	day, err := shiftpad.GetDay(srv, authpad.Pad, shift.Begin, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	if err := html.ShiftDelete.Execute(w, html.ShiftDeleteData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Day:   day,
+		Shift: shift,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) shiftDeletePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanEditShift(*shift) {
+		return NotFound()
+	}
+
+	if err := srv.DB.DeleteShift(shift); err != nil {
+		return InternalServerError(err)
+	}
+
+	if scope := r.PostFormValue("scope"); shift.SeriesID != 0 && (scope == shiftpad.ScopeFollowing || scope == shiftpad.ScopeSeries) {
+		others, err := srv.DB.GetShiftsBySeries(authpad.Pad, shift.SeriesID)
+		if err != nil {
+			return InternalServerError(err)
+		}
+		var errs []string
+		for _, other := range others {
+			if other.ID == shift.ID || (scope == shiftpad.ScopeFollowing && other.Begin.Before(shift.Begin)) {
+				continue
+			}
+			if !authpad.CanEditShift(other) {
+				errs = append(errs, fmt.Sprintf("deleting shift on %s: unauthorized", other.Begin.Format(time.DateOnly)))
+				continue
+			}
+			if err := srv.DB.DeleteShift(&other); err != nil {
+				return InternalServerError(err)
+			}
+		}
+		srv.sessionManager.Put(r.Context(), "errs", errs)
+	}
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+
+	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+}
+
+func (srv *Server) shiftEditGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanEditShift(*shift) {
+		return NotFound()
+	}
+
+	return srv.shiftEditTemplate(w, r, authpad, shift, "")
+}
+
+func (srv *Server) shiftEditTemplate(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, errMsg string) http.Handler {
+	day, err := shiftpad.GetDay(srv, authpad.Pad, shift.Begin, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	var minDate = time.Now()
+	if authpad.EditRetroAlways {
+		minDate = time.Date(2000, time.January, 1, 0, 0, 0, 0, authpad.Location)
+	}
+
+	if err := html.ShiftEdit.Execute(w, html.ShiftEditData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Day:     day,
+		MaxDate: time.Now().Add(shiftpad.MaxFuture).Format("2006-01-02"),
+		MinDate: minDate.Format("2006-01-02"),
+		Shift:   shift,
+		Error:   errMsg,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) shiftEditPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanEditShift(*shift) {
+		return NotFound()
+	}
+
+	begin, _ := time.ParseInLocation("2006-01-02T15:04", r.PostFormValue("begin"), authpad.Location)
+	end, _ := time.ParseInLocation("2006-01-02T15:04", r.PostFormValue("end"), authpad.Location)
+	if err := shiftpad.CheckBeginEnd(begin, end, authpad.EditRetroAlways, shiftpad.MaxFuture); err != nil {
+		return srv.shiftEditTemplate(w, r, authpad, shift, err.Error())
+	}
+
+	quantity, _ := strconv.Atoi(r.PostFormValue("quantity"))
+	quantity = min(quantity, 64)
+	name := trim(r.PostFormValue("name"), 64)
+	note := trim(r.PostFormValue("note"), 64)
+	paid := r.PostFormValue("paid") != ""
+	rate := parseFloat(r.PostFormValue("rate"))
+	brk := max(time.Duration(parseFloat(r.PostFormValue("break")))*time.Minute, authpad.MinBreak(end.Sub(begin)))
+	if brk >= end.Sub(begin) {
+		return srv.shiftEditTemplate(w, r, authpad, shift, "break must be shorter than the shift")
+	}
+	eventUID := trim(r.PostFormValue("event-uid"), 128)
+	oldBegin := shift.Begin
+	original := *shift
+
+	var takes []shiftpad.Take
+	// existing takes (take.ID must not be user input)
+	for _, take := range shift.Takes {
+		takerName := trim(r.PostFormValue(fmt.Sprintf("taker-name-%d", take.ID)), 64)
+		takerContact := trim(r.PostFormValue(fmt.Sprintf("taker-contact-%d", take.ID)), 128)
+		takeApproved := r.PostFormValue(fmt.Sprintf("approved-%d", take.ID)) != ""
+		if takerName != "" {
+			kept := shiftpad.Take{
+				ID:           take.ID, // keep existing id
+				Name:         takerName,
+				Contact:      takerContact,
+				Approved:     takeApproved,
+				PaidOut:      take.PaidOut, // keep existing payments
+				Rejected:     take.Rejected && !takeApproved,
+				RejectReason: take.RejectReason,
+				Offered:      take.Offered && takeApproved && takerName == take.Name, // an editor has handed over the take already
+			}
+			if takerName == take.Name && takeApproved {
+				// keep what has been recorded after the shift
+				kept.ActualBegin = take.ActualBegin
+				kept.ActualEnd = take.ActualEnd
+				kept.Attendance = take.Attendance
+			}
+			takes = append(takes, kept)
+		}
+	}
+	// new takes
+	var newNames = r.PostForm["new-name"]
+	if len(newNames) > 64 {
+		newNames = newNames[:64]
+	}
+	var newContacts = r.PostForm["new-contact"]
+	if len(newContacts) > 64 {
+		newContacts = newContacts[:64]
+	}
+	var newApproved = r.PostForm["new-approved"]
+	if len(newApproved) > 64 {
+		newApproved = newApproved[:64]
+	}
+	var activeTakes = 0
+	for _, take := range takes {
+		if !take.Rejected {
+			activeTakes++
+		}
+	}
+	maxNew := min(quantity-activeTakes, len(newNames), len(newContacts))
+	for i := 0; i < maxNew; i++ {
+		takerName := trim(newNames[i], 64)
+		takerContact := trim(newContacts[i], 128)
+		takeApproved := slices.Contains(newApproved, strconv.Itoa(i)) // Checkbox form input is sparse, so we can't use its indices. Instead we have submitted the form row indices.
+		if takerName != "" {
+			takes = append(takes, shiftpad.Take{
+				Name:     takerName,
+				Contact:  takerContact,
+				Approved: takeApproved,
+			})
+		}
+	}
+
+	shift.Name = name
+	shift.Note = note
+	shift.Paid = paid
+	shift.Rate = rate
+	shift.Break = brk
+	shift.EventUID = eventUID
+	shift.Quantity = quantity
+	shift.Begin = begin
+	shift.End = end
+	shift.Modified = time.Now()
+	shift.Takes = takes
+	shift.ResetRecorded(original)
+
+	if !authpad.CanEditShift(*shift) {
+		return NotFound()
+	}
+
+	errs, warns, err := srv.checkTakes(authpad, original, *shift)
+	if err != nil {
+		return InternalServerError(err)
+	}
+	if len(errs) > 0 {
+		return srv.shiftEditTemplate(w, r, authpad, &original, strings.Join(errs, "; "))
+	}
+
+	if err := srv.DB.UpdateShift(authpad.Pad, shift); err != nil {
+		return InternalServerError(err)
+	}
+
+	// apply changes to other shifts of the series, except for takes and event
+	if scope := r.PostFormValue("scope"); shift.SeriesID != 0 && (scope == shiftpad.ScopeFollowing || scope == shiftpad.ScopeSeries) {
+		others, err := srv.DB.GetShiftsBySeries(authpad.Pad, shift.SeriesID)
+		if err != nil {
+			return InternalServerError(err)
+		}
+		for _, other := range others {
+			if other.ID == shift.ID || (scope == shiftpad.ScopeFollowing && other.Begin.Before(oldBegin)) {
+				continue
+			}
+			if !authpad.CanEditShift(other) {
+				errs = append(errs, fmt.Sprintf("changing shift on %s: unauthorized", other.Begin.Format(time.DateOnly)))
+				continue
+			}
+			otherOriginal := other
+			other.Name = name
+			other.Note = note
+			other.Paid = paid
+			other.Rate = rate
+			other.Break = brk
+			other.Quantity = quantity
+			other.Begin, other.End = shiftpad.Reschedule(other.Begin, oldBegin, begin, end)
+			other.Modified = time.Now()
+			other.ResetRecorded(otherOriginal)
+			if !authpad.CanEditShift(other) {
+				errs = append(errs, fmt.Sprintf("changing shift on %s: unauthorized", other.Begin.Format(time.DateOnly)))
+				continue
+			}
+			otherErrs, otherWarns, err := srv.checkTakes(authpad, otherOriginal, other)
+			if err != nil {
+				return InternalServerError(err)
+			}
+			if len(otherErrs) > 0 {
+				errs = append(errs, fmt.Sprintf("changing shift on %s: %s", other.Begin.Format(time.DateOnly), strings.Join(otherErrs, "; ")))
+				continue
+			}
+			warns = append(warns, otherWarns...)
+			if err := srv.DB.UpdateShift(authpad.Pad, &other); err != nil {
+				return InternalServerError(err)
+			}
+		}
+		srv.sessionManager.Put(r.Context(), "errs", errs)
+	}
+	srv.sessionManager.Put(r.Context(), "warns", warns)
+
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+
+	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+}
+
+func (srv *Server) shiftApplyGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanApplyShift(*shift) {
+		return NotFound()
+	}
+
+	day, err := shiftpad.GetDay(srv, authpad.Pad, shift.Begin, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	if err := html.ShiftTake.Execute(w, html.ShiftTakeData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Apply:      true,
+		Day:        day,
+		Shift:      shift,
+		TakerNames: authpad.TakerName,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) shiftApplyPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanApplyShift(*shift) {
+		return NotFound()
+	}
+
+	takerName := trim(r.PostFormValue("taker-name"), 64)
+	takerContact := trim(r.PostFormValue("taker-contact"), 128)
+	if takerName == "" {
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	}
+	if !authpad.CanApplyName(*shift, takerName) {
+		return NotFound()
+	}
+
+	errs, warns, err := srv.checkTaker(authpad, *shift, takerName)
+	if err != nil {
+		return InternalServerError(err)
+	}
+	if len(errs) > 0 {
+		srv.sessionManager.Put(r.Context(), "errs", errs)
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	}
+
+	take := shiftpad.Take{
+		Name:     takerName,
+		Contact:  takerContact,
+		Approved: false,
+	}
+	if err := srv.DB.TakeShift(authpad.Pad, shift, take); errors.Is(err, shiftpad.ErrFullyTaken) {
+		srv.sessionManager.Put(r.Context(), "errs", []string{err.Error()})
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	} else if err != nil {
+		return InternalServerError(err)
+	}
+	srv.sessionManager.Put(r.Context(), "warns", warns)
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+
+	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+}
+
+func (srv *Server) takeApproveGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanTakerName(*shift, take.Name) || take.Rejected {
+		return NotFound()
+	}
+
+	day, err := shiftpad.GetDay(srv, authpad.Pad, shift.Begin, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	if err := html.TakeApprove.Execute(w, html.TakeApproveData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Day:   day,
+		Shift: shift,
+		Take:  take,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) takeApprovePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanTakerName(*shift, take.Name) || take.Rejected {
+		return NotFound()
+	}
+
+	if err := srv.DB.ApproveTake(shift, take); errors.Is(err, shiftpad.ErrFullyTaken) {
+		srv.sessionManager.Put(r.Context(), "errs", []string{err.Error()})
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	} else if err != nil {
+		return InternalServerError(err)
+	}
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+
+	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+}
+
+func (srv *Server) takeRejectGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanRejectTake(*shift, take) {
+		return NotFound()
+	}
+
+	day, err := shiftpad.GetDay(srv, authpad.Pad, shift.Begin, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	if err := html.TakeReject.Execute(w, html.TakeRejectData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Day:   day,
+		Shift: shift,
+		Take:  take,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
 
-		if err := srv.DB.AddShift(authpad.Pad, shift); err != nil {
-			return InternalServerError(err)
-		}
+func (srv *Server) takeRejectPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanRejectTake(*shift, take) {
+		return NotFound()
 	}
-	srv.sessionManager.Put(r.Context(), "errs", errs)
 
+	take.RejectReason = trim(r.PostFormValue("reason"), 128)
+	if err := srv.DB.RejectTake(shift, take); err != nil {
+		return InternalServerError(err)
+	}
 	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
 		return InternalServerError(err)
 	}
 
-	redirectDate := r.PathValue("date") // alternative: min shift or event begin time
-	return http.RedirectHandler(authpad.Link()+fmt.Sprintf("/day/%s", redirectDate), http.StatusSeeOther)
+	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
 }
 
-func (srv *Server) shiftDeleteGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
-	if !authpad.CanEditShift(*shift) {
+func (srv *Server) takeCancelGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanCancelTake(*shift, take) {
 		return NotFound()
 	}
 
@@ -895,25 +2336,26 @@ func (srv *Server) shiftDeleteGet(w http.ResponseWriter, r *http.Request, authpa
 		return InternalServerError(err)
 	}
 
-	if err := html.ShiftDelete.Execute(w, html.ShiftDeleteData{
+	if err := html.TakeCancel.Execute(w, html.TakeCancelData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
 			Pad:        authpad,
 		},
 		Day:   day,
 		Shift: shift,
+		Take:  take,
 	}); err != nil {
 		return InternalServerError(err)
 	}
 	return nil
 }
 
-func (srv *Server) shiftDeletePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
-	if !authpad.CanEditShift(*shift) {
+func (srv *Server) takeCancelPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanCancelTake(*shift, take) {
 		return NotFound()
 	}
 
-	if err := srv.DB.DeleteShift(shift); err != nil {
+	if err := srv.DB.CancelTake(shift, take); err != nil {
 		return InternalServerError(err)
 	}
 	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
@@ -923,117 +2365,151 @@ func (srv *Server) shiftDeletePost(w http.ResponseWriter, r *http.Request, authp
 	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
 }
 
-func (srv *Server) shiftEditGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
-	if !authpad.CanEditShift(*shift) {
+func (srv *Server) takeAttendanceGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanSetAttendance(*shift, take) {
 		return NotFound()
 	}
 
-	return srv.shiftEditTemplate(w, r, authpad, shift, "")
-}
-
-func (srv *Server) shiftEditTemplate(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, errMsg string) http.Handler {
 	day, err := shiftpad.GetDay(srv, authpad.Pad, shift.Begin, authpad.Location)
 	if err != nil {
 		return InternalServerError(err)
 	}
 
-	var minDate = time.Now()
-	if authpad.EditRetroAlways {
-		minDate = time.Date(2000, time.January, 1, 0, 0, 0, 0, authpad.Location)
-	}
-
-	if err := html.ShiftEdit.Execute(w, html.ShiftEditData{
+	if err := html.TakeAttendance.Execute(w, html.TakeAttendanceData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
 			Pad:        authpad,
 		},
-		Day:     day,
-		MaxDate: time.Now().Add(shiftpad.MaxFuture).Format("2006-01-02"),
-		MinDate: minDate.Format("2006-01-02"),
-		Shift:   shift,
-		Error:   errMsg,
+		Attendances: shiftpad.Attendances,
+		Day:         day,
+		Shift:       shift,
+		Take:        take,
 	}); err != nil {
 		return InternalServerError(err)
 	}
 	return nil
 }
 
-func (srv *Server) shiftEditPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
-	if !authpad.CanEditShift(*shift) {
+func (srv *Server) takeAttendancePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanSetAttendance(*shift, take) {
 		return NotFound()
 	}
 
-	begin, _ := time.ParseInLocation("2006-01-02T15:04", r.PostFormValue("begin"), authpad.Location)
-	end, _ := time.ParseInLocation("2006-01-02T15:04", r.PostFormValue("end"), authpad.Location)
-	if err := shiftpad.CheckBeginEnd(begin, end, authpad.EditRetroAlways, shiftpad.MaxFuture); err != nil {
-		return srv.shiftEditTemplate(w, r, authpad, shift, err.Error())
+	take.Attendance = shiftpad.ParseAttendance(r.PostFormValue("attendance"))
+	if err := srv.DB.SetAttendance(shift, take); err != nil {
+		return InternalServerError(err)
+	}
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
 	}
 
-	quantity, _ := strconv.Atoi(r.PostFormValue("quantity"))
-	quantity = min(quantity, 64)
-	name := trim(r.PostFormValue("name"), 64)
-	note := trim(r.PostFormValue("note"), 64)
-	paid := r.PostFormValue("paid") != ""
-	eventUID := trim(r.PostFormValue("event-uid"), 128)
+	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+}
 
-	var takes []shiftpad.Take
-	// existing takes (take.ID must not be user input)
-	for _, take := range shift.Takes {
-		takerName := trim(r.PostFormValue(fmt.Sprintf("taker-name-%d", take.ID)), 64)
-		takerContact := trim(r.PostFormValue(fmt.Sprintf("taker-contact-%d", take.ID)), 128)
-		takeApproved := r.PostFormValue(fmt.Sprintf("approved-%d", take.ID)) != ""
-		if takerName != "" {
-			takes = append(takes, shiftpad.Take{
-				ID:       take.ID, // keep existing id
-				Name:     takerName,
-				Contact:  takerContact,
-				Approved: takeApproved,
-				PaidOut:  take.PaidOut, // keep existing payments
-			})
+func (srv *Server) takeRecordGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanRecordTake(*shift, take) {
+		return NotFound()
+	}
+	return srv.takeRecordTemplate(w, r, authpad, shift, take, "")
+}
+
+func (srv *Server) takeRecordTemplate(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take, errMsg string) http.Handler {
+	if err := html.TakeRecord.Execute(w, html.TakeRecordData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Error: errMsg,
+		Shift: shift,
+		Take:  take,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) takeRecordPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanRecordTake(*shift, take) {
+		return NotFound()
+	}
+
+	if r.PostFormValue("reset") != "" {
+		take.ActualBegin = time.Time{}
+		take.ActualEnd = time.Time{}
+	} else {
+		begin, _ := time.ParseInLocation("2006-01-02T15:04", r.PostFormValue("begin"), authpad.Location)
+		end, _ := time.ParseInLocation("2006-01-02T15:04", r.PostFormValue("end"), authpad.Location)
+		if begin.IsZero() || end.IsZero() || !end.After(begin) {
+			return srv.takeRecordTemplate(w, r, authpad, shift, take, "Begin must be before end.")
 		}
+		if end.After(time.Now()) {
+			return srv.takeRecordTemplate(w, r, authpad, shift, take, "End must not be in the future.")
+		}
+		take.ActualBegin = begin
+		take.ActualEnd = end
 	}
-	// new takes
-	var newNames = r.PostForm["new-name"]
-	if len(newNames) > 64 {
-		newNames = newNames[:64]
+
+	if err := srv.DB.RecordTake(shift, take); err != nil {
+		return InternalServerError(err)
 	}
-	var newContacts = r.PostForm["new-contact"]
-	if len(newContacts) > 64 {
-		newContacts = newContacts[:64]
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
 	}
-	var newApproved = r.PostForm["new-approved"]
-	if len(newApproved) > 64 {
-		newApproved = newApproved[:64]
+
+	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+}
+
+func (srv *Server) takeOfferGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanOfferTake(*shift, take) || take.Offered {
+		return NotFound()
 	}
-	maxNew := min(quantity-len(takes), len(newNames), len(newContacts))
-	for i := 0; i < maxNew; i++ {
-		takerName := trim(newNames[i], 64)
-		takerContact := trim(newContacts[i], 128)
-		takeApproved := slices.Contains(newApproved, strconv.Itoa(i)) // Checkbox form input is sparse, so we can't use its indices. Instead we have submitted the form row indices.
-		if takerName != "" {
-			takes = append(takes, shiftpad.Take{
-				Name:     takerName,
-				Contact:  takerContact,
-				Approved: takeApproved,
-			})
-		}
+	return srv.takeOfferTemplate(w, r, authpad, shift, take, false)
+}
+
+func (srv *Server) takeOfferPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanOfferTake(*shift, take) || take.Offered {
+		return NotFound()
 	}
+	return srv.takeOffer(r, authpad, shift, take, true)
+}
 
-	shift.Name = name
-	shift.Note = note
-	shift.Paid = paid
-	shift.EventUID = eventUID
-	shift.Quantity = quantity
-	shift.Begin = begin
-	shift.End = end
-	shift.Modified = time.Now()
-	shift.Takes = takes
+func (srv *Server) takeWithdrawGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanOfferTake(*shift, take) || !take.Offered {
+		return NotFound()
+	}
+	return srv.takeOfferTemplate(w, r, authpad, shift, take, true)
+}
 
-	if !authpad.CanEditShift(*shift) {
+func (srv *Server) takeWithdrawPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanOfferTake(*shift, take) || !take.Offered {
 		return NotFound()
 	}
+	return srv.takeOffer(r, authpad, shift, take, false)
+}
 
-	if err := srv.DB.UpdateShift(authpad.Pad, shift); err != nil {
+func (srv *Server) takeOfferTemplate(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take, withdraw bool) http.Handler {
+	day, err := shiftpad.GetDay(srv, authpad.Pad, shift.Begin, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	if err := html.TakeOffer.Execute(w, html.TakeOfferData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Day:      day,
+		Shift:    shift,
+		Take:     take,
+		Withdraw: withdraw,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) takeOffer(r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take, offered bool) http.Handler {
+	if err := srv.DB.OfferTake(shift, take, offered); err != nil {
 		return InternalServerError(err)
 	}
 	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
@@ -1043,8 +2519,8 @@ func (srv *Server) shiftEditPost(w http.ResponseWriter, r *http.Request, authpad
 	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
 }
 
-func (srv *Server) shiftApplyGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
-	if !authpad.CanApplyShift(*shift) {
+func (srv *Server) takeAcceptGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanAcceptOffer(*shift, take) {
 		return NotFound()
 	}
 
@@ -1053,23 +2529,23 @@ func (srv *Server) shiftApplyGet(w http.ResponseWriter, r *http.Request, authpad
 		return InternalServerError(err)
 	}
 
-	if err := html.ShiftTake.Execute(w, html.ShiftTakeData{
+	if err := html.TakeAccept.Execute(w, html.TakeAcceptData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
 			Pad:        authpad,
 		},
-		Apply:      true,
 		Day:        day,
 		Shift:      shift,
-		TakerNames: authpad.TakerName,
+		Take:       take,
+		TakerNames: slices.DeleteFunc(slices.Clone(authpad.TakerName), func(name string) bool { return name == take.Name }),
 	}); err != nil {
 		return InternalServerError(err)
 	}
 	return nil
 }
 
-func (srv *Server) shiftApplyPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
-	if !authpad.CanApplyShift(*shift) {
+func (srv *Server) takeAcceptPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
+	if !authpad.CanAcceptOffer(*shift, take) {
 		return NotFound()
 	}
 
@@ -1078,18 +2554,33 @@ func (srv *Server) shiftApplyPost(w http.ResponseWriter, r *http.Request, authpa
 	if takerName == "" {
 		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
 	}
-	if !authpad.CanApplyName(*shift, takerName) {
-		return NotFound()
+	if !authpad.CanAcceptOfferName(*shift, take, takerName) {
+		return Forbidden()
+	}
+	if shift.HasTaker(takerName) {
+		srv.sessionManager.Put(r.Context(), "errs", []string{fmt.Sprintf("%s has already taken this shift", takerName)})
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	}
+	errs, warns, err := srv.checkTaker(authpad, *shift, takerName)
+	if err != nil {
+		return InternalServerError(err)
+	}
+	if len(errs) > 0 {
+		srv.sessionManager.Put(r.Context(), "errs", errs)
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
 	}
 
-	take := shiftpad.Take{
-		Name:     takerName,
-		Contact:  takerContact,
-		Approved: false,
+	newTake := shiftpad.Take{
+		Name:    takerName,
+		Contact: takerContact,
 	}
-	if err := srv.DB.TakeShift(authpad.Pad, shift, take); err != nil {
+	if err := srv.DB.AcceptOffer(shift, take, newTake); errors.Is(err, shiftpad.ErrOfferGone) {
+		srv.sessionManager.Put(r.Context(), "errs", []string{err.Error()})
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	} else if err != nil {
 		return InternalServerError(err)
 	}
+	srv.sessionManager.Put(r.Context(), "warns", warns)
 	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
 		return InternalServerError(err)
 	}
@@ -1097,8 +2588,8 @@ func (srv *Server) shiftApplyPost(w http.ResponseWriter, r *http.Request, authpa
 	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
 }
 
-func (srv *Server) takeApproveGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
-	if !authpad.CanTakerName(*shift, take.Name) {
+func (srv *Server) shiftTakeGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanTakeShift(*shift) {
 		return NotFound()
 	}
 
@@ -1107,28 +2598,56 @@ func (srv *Server) takeApproveGet(w http.ResponseWriter, r *http.Request, authpa
 		return InternalServerError(err)
 	}
 
-	if err := html.TakeApprove.Execute(w, html.TakeApproveData{
+	if err := html.ShiftTake.Execute(w, html.ShiftTakeData{
 		PadData: html.PadData{
 			LayoutData: html.MakeLayoutData(r),
 			Pad:        authpad,
 		},
-		Day:   day,
-		Shift: shift,
-		Take:  take,
+		Apply:      false,
+		Day:        day,
+		Shift:      shift,
+		TakerNames: authpad.TakerName,
 	}); err != nil {
 		return InternalServerError(err)
 	}
 	return nil
 }
 
-func (srv *Server) takeApprovePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, take shiftpad.Take) http.Handler {
-	if !authpad.CanTakerName(*shift, take.Name) {
+func (srv *Server) shiftTakePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanTakeShift(*shift) {
 		return NotFound()
 	}
 
-	if err := srv.DB.ApproveTake(shift, take); err != nil {
+	takerName := trim(r.PostFormValue("taker-name"), 64)
+	takerContact := trim(r.PostFormValue("taker-contact"), 128)
+	if takerName == "" {
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	}
+	if !authpad.CanTakerName(*shift, takerName) {
+		return Forbidden()
+	}
+
+	errs, warns, err := srv.checkTaker(authpad, *shift, takerName)
+	if err != nil {
+		return InternalServerError(err)
+	}
+	if len(errs) > 0 {
+		srv.sessionManager.Put(r.Context(), "errs", errs)
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	}
+
+	take := shiftpad.Take{
+		Name:     takerName,
+		Contact:  takerContact,
+		Approved: true,
+	}
+	if err := srv.DB.TakeShift(authpad.Pad, shift, take); errors.Is(err, shiftpad.ErrFullyTaken) {
+		srv.sessionManager.Put(r.Context(), "errs", []string{err.Error()})
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	} else if err != nil {
 		return InternalServerError(err)
 	}
+	srv.sessionManager.Put(r.Context(), "warns", warns)
 	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
 		return InternalServerError(err)
 	}
@@ -1136,8 +2655,8 @@ func (srv *Server) takeApprovePost(w http.ResponseWriter, r *http.Request, authp
 	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
 }
 
-func (srv *Server) shiftTakeGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
-	if !authpad.CanTakeShift(*shift) {
+func (srv *Server) shiftWaitGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanWaitShift(*shift) {
 		return NotFound()
 	}
 
@@ -1151,7 +2670,7 @@ func (srv *Server) shiftTakeGet(w http.ResponseWriter, r *http.Request, authpad
 			LayoutData: html.MakeLayoutData(r),
 			Pad:        authpad,
 		},
-		Apply:      false,
+		Wait:       true,
 		Day:        day,
 		Shift:      shift,
 		TakerNames: authpad.TakerName,
@@ -1161,8 +2680,8 @@ func (srv *Server) shiftTakeGet(w http.ResponseWriter, r *http.Request, authpad
 	return nil
 }
 
-func (srv *Server) shiftTakePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
-	if !authpad.CanTakeShift(*shift) {
+func (srv *Server) shiftWaitPost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift) http.Handler {
+	if !authpad.CanWaitShift(*shift) {
 		return NotFound()
 	}
 
@@ -1171,16 +2690,59 @@ func (srv *Server) shiftTakePost(w http.ResponseWriter, r *http.Request, authpad
 	if takerName == "" {
 		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
 	}
-	if !authpad.CanTakerName(*shift, takerName) {
+	if !authpad.CanWaitName(*shift, takerName) {
 		return Forbidden()
 	}
+	if shift.HasTakerOrWaiter(takerName) {
+		srv.sessionManager.Put(r.Context(), "errs", []string{fmt.Sprintf("%s has already taken or is waiting for this shift", takerName)})
+		return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+	}
 
-	take := shiftpad.Take{
-		Name:     takerName,
-		Contact:  takerContact,
-		Approved: true,
+	waiter := shiftpad.Waiter{
+		Name:    takerName,
+		Contact: takerContact,
+		Take:    authpad.CanTake(shift.Name),
+	}
+	if err := srv.DB.AddWaiter(shift, waiter); err != nil {
+		return InternalServerError(err)
+	}
+	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
+		return InternalServerError(err)
+	}
+
+	return http.RedirectHandler(linkDay(authpad, shift.Begin), http.StatusSeeOther)
+}
+
+func (srv *Server) waiterLeaveGet(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, waiter shiftpad.Waiter) http.Handler {
+	if !authpad.CanLeaveWaitlist(*shift, waiter) {
+		return NotFound()
+	}
+
+	day, err := shiftpad.GetDay(srv, authpad.Pad, shift.Begin, authpad.Location)
+	if err != nil {
+		return InternalServerError(err)
+	}
+
+	if err := html.WaiterLeave.Execute(w, html.WaiterLeaveData{
+		PadData: html.PadData{
+			LayoutData: html.MakeLayoutData(r),
+			Pad:        authpad,
+		},
+		Day:    day,
+		Shift:  shift,
+		Waiter: waiter,
+	}); err != nil {
+		return InternalServerError(err)
+	}
+	return nil
+}
+
+func (srv *Server) waiterLeavePost(w http.ResponseWriter, r *http.Request, authpad shiftpad.AuthPad, shift *shiftpad.Shift, waiter shiftpad.Waiter) http.Handler {
+	if !authpad.CanLeaveWaitlist(*shift, waiter) {
+		return NotFound()
 	}
-	if err := srv.DB.TakeShift(authpad.Pad, shift, take); err != nil {
+
+	if err := srv.DB.DeleteWaiter(shift, waiter); err != nil {
 		return InternalServerError(err)
 	}
 	if err := srv.UpdatePadLastUpdated(authpad.Pad); err != nil {
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/wansing/shiftpad"
+	"github.com/wansing/shiftpad/html"
+	"github.com/wansing/shiftpad/pdf"
+)
+
+// column positions of the receipt table
+const (
+	receiptMargin  = 50.0
+	receiptShift   = 230.0
+	receiptHours   = 400.0
+	receiptAmount  = 460.0
+	receiptLineGap = 14.0
+)
+
+// makeReceipt creates a printable receipt of the payout. The shifts contain the takes of the payout, takes which have been deleted in the meantime are listed by their shift name only.
+// The sum is taken from the payout, so it is what has been recorded as paid out. The total adds the surcharges to it, it is what the recipient signs for.
+func makeReceipt(lang html.Lang, authpad shiftpad.AuthPad, payout shiftpad.Payout, shifts []shiftpad.Shift) *pdf.Document {
+	var doc pdf.Document
+	var y = 60.0
+	newline := func(gap float64) {
+		y += gap
+		if y > pdf.PageHeight-receiptMargin {
+			doc.AddPage()
+			y = 60
+		}
+	}
+
+	doc.Text(receiptMargin, y, 16, true, lang.Tr("Payout receipt"))
+	newline(28)
+	doc.Text(receiptMargin, y, 10, false, lang.Tr("Pad")+": "+authpad.Name)
+	newline(receiptLineGap)
+	doc.Text(receiptMargin, y, 10, false, lang.Tr("Taker")+": "+payout.Taker)
+	newline(receiptLineGap)
+	doc.Text(receiptMargin, y, 10, false, lang.Tr("Paid out by")+": "+payout.Payer)
+	newline(receiptLineGap)
+	doc.Text(receiptMargin, y, 10, false, lang.Tr("Date")+": "+payout.Created.In(authpad.Location).Format("2. Jan 2006 15:04"))
+	newline(28)
+
+	doc.Text(receiptMargin, y, 10, true, lang.Tr("Time"))
+	doc.Text(receiptShift, y, 10, true, lang.Tr("Shift"))
+	doc.Text(receiptHours, y, 10, true, lang.Tr("Hours"))
+	doc.Text(receiptAmount, y, 10, true, lang.Tr("Amount"))
+	newline(6)
+	doc.Line(receiptMargin, y, pdf.PageWidth-receiptMargin, y)
+	newline(receiptLineGap)
+
+	var total shiftpad.PayoutTotal
+	var found = make(map[int]bool)
+	for _, shift := range shifts {
+		for _, take := range shift.Takes {
+			total.Add(authpad.Pad, shift, take)
+			found[take.ID] = true
+
+			worked := shift.Worked(take)
+			name := shift.Name
+			if shift.Note != "" {
+				name += " (" + shift.Note + ")"
+			}
+			doc.Text(receiptMargin, y, 10, false, html.FmtDateTimeRange(worked.Begin, worked.End))
+			doc.Text(receiptShift, y, 10, false, truncate(name, 30))
+			doc.Text(receiptHours, y, 10, false, fmtFloat2(worked.Hours()))
+			if authpad.Rate(shift) > 0 {
+				doc.Text(receiptAmount, y, 10, false, fmtFloat2(authpad.Amount(worked))+" "+authpad.Currency)
+			}
+			newline(receiptLineGap)
+		}
+	}
+	for _, take := range payout.Takes {
+		if !found[take.TakeID] {
+			doc.Text(receiptShift, y, 10, false, truncate(take.ShiftName, 30))
+			newline(receiptLineGap)
+		}
+	}
+
+	newline(-8)
+	doc.Line(receiptMargin, y, pdf.PageWidth-receiptMargin, y)
+	newline(receiptLineGap)
+	doc.Text(receiptMargin, y, 10, true, lang.Tr("Sum"))
+	doc.Text(receiptHours, y, 10, true, fmtFloat2(payout.Hours))
+	if payout.Amount > 0 {
+		doc.Text(receiptAmount, y, 10, true, fmtFloat2(payout.Amount)+" "+authpad.Currency)
+	}
+	var paidOut = payout.Amount
+	for _, surcharge := range total.Surcharges {
+		newline(receiptLineGap)
+		doc.Text(receiptMargin, y, 10, false, lang.Tr("Surcharge")+" "+surcharge.Name+" +"+fmtFloat2(surcharge.Percent)+"%")
+		doc.Text(receiptHours, y, 10, false, fmtFloat2(surcharge.Hours))
+		if surcharge.Amount > 0 {
+			doc.Text(receiptAmount, y, 10, false, fmtFloat2(surcharge.Amount)+" "+authpad.Currency)
+		}
+		paidOut += surcharge.Amount
+	}
+	if paidOut > 0 {
+		newline(6)
+		doc.Line(receiptAmount, y, pdf.PageWidth-receiptMargin, y)
+		newline(receiptLineGap)
+		doc.Text(receiptMargin, y, 10, true, lang.Tr("Total paid out"))
+		doc.Text(receiptAmount, y, 10, true, fmtFloat2(paidOut)+" "+authpad.Currency)
+	}
+
+	// signature lines
+	newline(80)
+	doc.Line(receiptMargin, y, 260, y)
+	doc.Line(pdf.PageWidth-receiptMargin-210, y, pdf.PageWidth-receiptMargin, y)
+	newline(receiptLineGap)
+	doc.Text(receiptMargin, y, 8, false, lang.Tr("Place, date, signature of the payer"))
+	doc.Text(pdf.PageWidth-receiptMargin-210, y, 8, false, lang.Tr("Place, date, signature of the recipient"))
+
+	return &doc
+}
+
+func fmtFloat2(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+// truncate shortens s to at most n runes, so it fits into a column.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
@@ -11,23 +11,44 @@ import (
 )
 
 type DB interface {
+	AcceptOffer(shift *shiftpad.Shift, take, newTake shiftpad.Take) error
+	AddCalDAVShift(pad *shiftpad.Pad, shift shiftpad.Shift, object string) error
 	AddPad(shiftpad.Pad) error
+	AddPayout(*shiftpad.Pad, shiftpad.Payout) (int, error)
 	AddShare(pad shiftpad.Pad, id string, auth shiftpad.Auth) error
+	AddSeries(*shiftpad.Pad, []shiftpad.Shift) error
 	AddShift(*shiftpad.Pad, shiftpad.Shift) error
+	AddShifts(*shiftpad.Pad, []shiftpad.Shift) error
+	AddWaiter(*shiftpad.Shift, shiftpad.Waiter) error
 	ApproveTake(*shiftpad.Shift, shiftpad.Take) error
+	CancelTake(*shiftpad.Shift, shiftpad.Take) error
 	DeletePad(shiftpad.Pad) error
 	DeletePads(string) error
+	DeleteShare(pad *shiftpad.Pad, secret string) error
 	DeleteShift(*shiftpad.Shift) error
+	DeleteWaiter(*shiftpad.Shift, shiftpad.Waiter) error
 	GetAuthPad(id, secret string) (shiftpad.AuthPad, error)
+	GetCalDAVShift(pad *shiftpad.Pad, object string) (*shiftpad.Shift, error)
+	GetCalDAVObjects(*shiftpad.Pad) (map[int]string, error)
+	GetPayout(pad *shiftpad.Pad, id int) (shiftpad.Payout, error)
+	GetPayoutSums(pad *shiftpad.Pad, from, to int64) ([]shiftpad.PayoutSum, error) // begin: from inclusive, to exclusive
+	GetPayouts(*shiftpad.Pad) ([]shiftpad.Payout, error)
+	GetShares(*shiftpad.Pad) ([]shiftpad.Share, error)
 	GetShift(pad *shiftpad.Pad, shift int) (*shiftpad.Shift, error)
 	GetShifts(pad *shiftpad.Pad, from, to int64) ([]shiftpad.Shift, error) // begin: from inclusive, to exclusive
 	GetShiftsByEvent(pad *shiftpad.Pad, eventUID string) ([]shiftpad.Shift, error)
-	GetTakerNames(*shiftpad.Pad) ([]string, error)
+	GetShiftsBySeries(pad *shiftpad.Pad, seriesID int) ([]shiftpad.Shift, error)
+	GetTakerNames(pad *shiftpad.Pad, shiftname func(string) bool) ([]string, error)
 	GetTakesByTaker(pad *shiftpad.Pad, name string) ([]shiftpad.Shift, error)
-	SetPaidOut([]shiftpad.Take) error
+	OfferTake(shift *shiftpad.Shift, take shiftpad.Take, offered bool) error
+	RecordTake(*shiftpad.Shift, shiftpad.Take) error
+	RejectTake(*shiftpad.Shift, shiftpad.Take) error
+	SetAttendance(*shiftpad.Shift, shiftpad.Take) error
 	TakeShift(*shiftpad.Pad, *shiftpad.Shift, shiftpad.Take) error
+	UndoPayout(*shiftpad.Pad, shiftpad.Payout) error
 	UpdatePad(*shiftpad.Pad) error
 	UpdatePadLastUpdated(pad *shiftpad.Pad, lastUpdated string) error
+	UpdateShare(*shiftpad.Pad, shiftpad.Share) error
 	UpdateShift(*shiftpad.Pad, *shiftpad.Shift) error
 }
 
@@ -1,6 +1,10 @@
 package main
 
-import "strings"
+import (
+	"math"
+	"strconv"
+	"strings"
+)
 
 func split(s string) []string {
 	fields := strings.FieldsFunc(s, func(r rune) bool { return r == '\r' || r == '\n' })
@@ -17,3 +21,12 @@ func trim(s string, maxlen int) string {
 	}
 	return s
 }
+
+// parseFloat parses a non-negative number like hours or a rate. Invalid input results in zero, which means unset.
+func parseFloat(s string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) || f < 0 {
+		return 0
+	}
+	return f
+}
@@ -0,0 +1,94 @@
+package shiftpad
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Conflict is a pair of overlapping shifts which are both taken by the same taker name.
+type Conflict struct {
+	Name  string
+	Shift Shift
+	Other Shift // begins at or after Shift
+}
+
+// Conflicts returns all pairs of overlapping shifts with the same taker name. Rejected takes are ignored.
+// The result is sorted by name and begin.
+func Conflicts(shifts []Shift) []Conflict {
+	var conflicts []Conflict
+	for name, shifts := range shiftsByTaker(shifts) {
+		sortShifts(shifts)
+		for i := range shifts {
+			for j := i + 1; j < len(shifts); j++ {
+				if !shifts[j].Begin.Before(shifts[i].End) {
+					break // sorted by begin, so no later shift overlaps shifts[i]
+				}
+				if overlaps(shifts[i].Begin, shifts[i].End, shifts[j].Begin, shifts[j].End) {
+					conflicts = append(conflicts, Conflict{
+						Name:  name,
+						Shift: shifts[i],
+						Other: shifts[j],
+					})
+				}
+			}
+		}
+	}
+	slices.SortFunc(conflicts, func(a, b Conflict) int {
+		if c := cmp.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return a.Shift.Begin.Compare(b.Shift.Begin)
+	})
+	return conflicts
+}
+
+// shiftsByTaker groups shifts by the names of their takes. Rejected takes are ignored.
+func shiftsByTaker(shifts []Shift) map[string][]Shift {
+	var byName = make(map[string][]Shift)
+	for _, shift := range shifts {
+		for _, take := range shift.Takes {
+			if take.Rejected || take.Name == "" {
+				continue
+			}
+			if !slices.ContainsFunc(byName[take.Name], func(s Shift) bool { return s.ID == shift.ID }) {
+				byName[take.Name] = append(byName[take.Name], shift)
+			}
+		}
+	}
+	return byName
+}
+
+// Overlapping returns those of others which overlap shift, except shift itself and shifts in which name has only rejected takes.
+// It is used with the result of Repository.GetTakesByTaker.
+func Overlapping(shift Shift, name string, others []Shift) []Shift {
+	var result []Shift
+	for _, other := range others {
+		if other.ID == shift.ID || !other.HasTaker(name) {
+			continue
+		}
+		if overlaps(shift.Begin, shift.End, other.Begin, other.End) {
+			result = append(result, other)
+		}
+	}
+	sortShifts(result)
+	return result
+}
+
+// ChangedTakers returns the names whose takes must be checked for overlaps and limits when original is changed to shift.
+// If begin or end have changed, these are the names of all takes which are not rejected. Else only new and renamed takes and takes which are not rejected any more are returned, so violations which existed before, for example after a limit has been lowered, don't block unrelated changes.
+func ChangedTakers(original, shift Shift) []string {
+	moved := !shift.Begin.Equal(original.Begin) || !shift.End.Equal(original.End)
+	var names []string
+	for _, take := range shift.Takes {
+		if take.Rejected || slices.Contains(names, take.Name) {
+			continue
+		}
+		if !moved && take.ID != 0 && slices.ContainsFunc(original.Takes, func(old Take) bool {
+			return old.ID == take.ID && old.Name == take.Name && !old.Rejected
+		}) {
+			continue
+		}
+		names = append(names, take.Name)
+	}
+	return names
+}
@@ -0,0 +1,69 @@
+package shiftpad
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestConflicts(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2025, time.March, 1, hour, 0, 0, 0, time.UTC)
+	}
+	shifts := []Shift{
+		{ID: 1, Begin: at(8), End: at(12), Takes: []Take{{Name: "alice", Approved: true}, {Name: "bob", Approved: true}}},
+		{ID: 2, Begin: at(11), End: at(14), Takes: []Take{{Name: "alice", Approved: true}, {Name: "bob", Rejected: true}}},
+		{ID: 3, Begin: at(12), End: at(16), Takes: []Take{{Name: "bob"}}}, // adjacent to 1, application overlaps 2
+		{ID: 4, Begin: at(13), End: at(15), Takes: []Take{{Name: "alice", Approved: true}}},
+	}
+
+	got := Conflicts(shifts)
+	want := []struct {
+		name         string
+		shift, other int
+	}{
+		{"alice", 1, 2},
+		{"alice", 2, 4},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d conflicts, want %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Name != w.name || got[i].Shift.ID != w.shift || got[i].Other.ID != w.other {
+			t.Fatalf("conflict %d: got %s %d %d, want %s %d %d", i, got[i].Name, got[i].Shift.ID, got[i].Other.ID, w.name, w.shift, w.other)
+		}
+	}
+
+	overlapping := Overlapping(Shift{ID: 5, Begin: at(11), End: at(13)}, "bob", shifts)
+	if len(overlapping) != 2 || overlapping[0].ID != 1 || overlapping[1].ID != 3 {
+		t.Fatalf("overlapping: got %v", overlapping)
+	}
+}
+
+func TestChangedTakers(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2025, time.March, 1, hour, 0, 0, 0, time.UTC)
+	}
+	original := Shift{ID: 1, Begin: at(8), End: at(12), Takes: []Take{
+		{ID: 1, Name: "alice", Approved: true},
+		{ID: 2, Name: "bob", Approved: true},
+		{ID: 3, Name: "carol", Rejected: true},
+	}}
+
+	shift := original
+	shift.Takes = []Take{
+		{ID: 1, Name: "alice", Approved: true},
+		{ID: 2, Name: "dave", Approved: true},  // renamed
+		{ID: 3, Name: "carol", Approved: true}, // not rejected any more
+		{Name: "erin"},                         // new
+	}
+	if got := ChangedTakers(original, shift); !slices.Equal(got, []string{"dave", "carol", "erin"}) {
+		t.Fatalf("got %v", got)
+	}
+
+	shift = original
+	shift.End = at(13)
+	if got := ChangedTakers(original, shift); !slices.Equal(got, []string{"alice", "bob"}) {
+		t.Fatalf("moved: got %v", got)
+	}
+}
@@ -70,7 +70,13 @@ type Timespan struct {
 
 // date must be any time in the week
 func GetWeek(repo Repository, pad *Pad, year, week int, location *time.Location) (Timespan, error) {
-	// get start of week
+	begin := WeekBegin(year, week, location)
+	to := begin.AddDate(0, 0, 7)
+	return getTimespan(repo, pad, begin, to, location)
+}
+
+// WeekBegin returns midnight of the monday of the given ISO week.
+func WeekBegin(year, week int, location *time.Location) time.Time {
 	// "Jan 01 to Jan 03 of year n might belong to week 52 or 53 of year n-1", so January 4th is always in week one
 	begin := time.Date(year, time.January, 4, 0, 0, 0, 0, location)
 	begin = begin.AddDate(0, 0, 7*(week-1))
@@ -78,8 +84,7 @@ func GetWeek(repo Repository, pad *Pad, year, week int, location *time.Location)
 	for begin.Weekday() != time.Monday {
 		begin = begin.AddDate(0, 0, -1)
 	}
-	to := begin.AddDate(0, 0, 7)
-	return getTimespan(repo, pad, begin, to, location)
+	return begin
 }
 
 func GetMonth(repo Repository, pad *Pad, year int, month int, location *time.Location) (Timespan, error) {
@@ -0,0 +1,23 @@
+package shiftpad
+
+import "slices"
+
+// ShiftFilter selects shifts, for example for the iCalendar export. Zero fields don't restrict the selection.
+type ShiftFilter struct {
+	Names      []string // shift names
+	Open       bool     // shifts which are not fully taken
+	TakerNames []string // shifts which have a take of one of these names which has not been rejected
+}
+
+func (filter ShiftFilter) Match(shift Shift) bool {
+	if len(filter.Names) > 0 && !slices.Contains(filter.Names, shift.Name) {
+		return false
+	}
+	if filter.Open && shift.FullyTaken() {
+		return false
+	}
+	if len(filter.TakerNames) > 0 && !slices.ContainsFunc(filter.TakerNames, shift.HasTaker) {
+		return false
+	}
+	return true
+}
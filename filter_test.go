@@ -0,0 +1,29 @@
+package shiftpad
+
+import "testing"
+
+func TestShiftFilter(t *testing.T) {
+	open := Shift{Name: "a", Quantity: 2, Takes: []Take{{Name: "x", Approved: true}, {Name: "y", Rejected: true}}}
+	full := Shift{Name: "b", Quantity: 1, Takes: []Take{{Name: "y", Approved: true}}}
+
+	tests := []struct {
+		filter     ShiftFilter
+		open, full bool
+	}{
+		{ShiftFilter{}, true, true},
+		{ShiftFilter{Names: []string{"a"}}, true, false},
+		{ShiftFilter{Names: []string{"a", "b"}}, true, true},
+		{ShiftFilter{Open: true}, true, false},
+		{ShiftFilter{TakerNames: []string{"y"}}, false, true}, // rejected take does not count
+		{ShiftFilter{TakerNames: []string{"x", "y"}}, true, true},
+		{ShiftFilter{Names: []string{"b"}, Open: true}, false, false},
+	}
+	for i, test := range tests {
+		if got := test.filter.Match(open); got != test.open {
+			t.Fatalf("test %d: open shift: got %v, want %v", i, got, test.open)
+		}
+		if got := test.filter.Match(full); got != test.full {
+			t.Fatalf("test %d: fully taken shift: got %v, want %v", i, got, test.full)
+		}
+	}
+}
@@ -7,6 +7,7 @@ require (
 	github.com/emersion/go-ical v0.0.0-20240127095438-fc1c9d8fb2b6
 	github.com/gorhill/cronexpr v0.0.0-20180427100037-88b0669f7d75
 	github.com/mattn/go-sqlite3 v1.14.16
+	github.com/teambition/rrule-go v1.8.2
 	github.com/wansing/go-ical-cache v0.0.0-20250106201613-5894c9a23e66
 	gitlab.com/golang-commonmark/markdown v0.0.0-20211110145824-bf3e522c626a
 	golang.org/x/exp v0.0.0-20240325151524-a685a6edb6d8
@@ -14,7 +15,6 @@ require (
 )
 
 require (
-	github.com/teambition/rrule-go v1.8.2 // indirect
 	gitlab.com/golang-commonmark/html v0.0.0-20191124015941-a22733972181 // indirect
 	gitlab.com/golang-commonmark/linkify v0.0.0-20191026162114-a0c2df6c8f82 // indirect
 	gitlab.com/golang-commonmark/mdurl v0.0.0-20191124015652-932350d1cb84 // indirect
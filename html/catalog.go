@@ -39,190 +39,509 @@ func init() {
 }
 
 var messageKeyToIndex = map[string]int{
-	"Administrate this Pad":          26,
-	"Administrate this pad":          27,
-	"Any shift":                      29,
-	"Any taker name":                 38,
-	"Apply":                          62,
-	"Apply for Shifts":               34,
-	"Apply for shift":                79,
-	"Approve take":                   81,
-	"Back":                           23,
-	"Begin":                          68,
-	"Begin must be before end.":      70,
-	"Cancel":                         48,
-	"Contact":                        76,
-	"Copy iCalendar":                 55,
-	"Copy link":                      25,
-	"Create new Pad":                 3,
-	"Create share link":              47,
-	"Create shifts":                  60,
-	"Create, Edit and Delete Shifts": 28,
-	"Cron expression, example":       37,
-	"Deadline (optional)":            36,
-	"Delete":                         64,
-	"Delete shift":                   74,
-	"Description (Markdown)":         18,
-	"Edit":                           63,
-	"Edit retroactively":             30,
-	"End":                            69,
-	"Error":                          59,
-	"Expires":                        45,
-	"Link Properties":                44,
-	"Link expires":                   54,
-	"Location":                       19,
-	"Mark any shift as paid out":     32,
-	"Mark as paid out":               16,
-	"Name":                           17,
-	"No shifts or events yet.":       65,
-	"No shifts.":                     13,
-	"Note":                           46,
-	"Paid out":                       8,
-	"Paid shifts taken by":           14,
-	"Payout":                         31,
-	"Please use the full link.":      2,
-	"Quantity":                       71,
-	"Save":                           22,
-	"Save changes":                   77,
-	"Settings":                       56,
-	"Share":                          57,
-	"Shift":                          6,
-	"Shift Names (one name per row)": 20,
-	"Shift name":                     72,
+	"A break rule sets the minimum unpaid break of shifts which are longer than the given length. The break is deducted from the hours of the shift.":                                                                                  75,
+	"A surcharge rule consists of a name, a percentage of the hourly rate and optionally weekdays (Mon to Sun), \"holidays\" and a time window. Hours are split in the location of the pad. Surcharges are shown on the payout pages.": 73,
+	"Accept handover":          142,
+	"Actual begin":             198,
+	"Actual end":               199,
+	"Actual worked time":       47,
+	"Administrate this Pad":    150,
+	"Administrate this pad":    91,
+	"All shifts of the series": 182,
+	"All values in hours. Shifts count towards the day, week and month in which they begin. Rejected applications are not counted.": 21,
+	"Amount":           60,
+	"Any shift":        93,
+	"Any taker name":   102,
+	"Apply":            97,
+	"Apply for Shifts": 154,
+	"Apply for shift":  189,
+	"Approve":          135,
+	"Approve take":     192,
+	"Approved takes of paid shifts which begin in this period. The number of takes is given in parentheses.":                                            42,
+	"Approved takes of shifts which are over and begin in the date range. Reliability is the share of attended takes among attended and no-show takes.": 12,
+	"Attendance":                             120,
+	"Back":                                   38,
+	"Begin":                                  171,
+	"Begin must be before end.":              173,
+	"Break":                                  174,
+	"Busiest day":                            18,
+	"Calendar collection for CalDAV clients": 115,
+	"Cancel":                                 147,
+	"Cancel deadline (optional)":             100,
+	"Cancel own takes":                       99,
+	"Cancel take":                            137,
+	"Conflicts":                              124,
+	"Contact":                                187,
+	"Copy CalDAV":                            116,
+	"Copy day":                               128,
+	"Copy iCalendar":                         114,
+	"Copy link":                              86,
+	"Copy my iCalendar":                      118,
+	"Copy shifts":                            169,
+	"Copy week":                              110,
+	"Create new Pad":                         17,
+	"Create share link":                      162,
+	"Create shifts":                          129,
+	"Create, Edit and Delete Shifts":         151,
+	"Cron expression or time before begin, example": 158,
+	"Cron expression, example":                      156,
+	"Currency":                                      69,
+	"Date":                                          56,
+	"Deadline (optional)":                           98,
+	"Delete":                                        106,
+	"Delete share link":                             148,
+	"Delete shift":                                  183,
+	"Description (Markdown)":                        65,
+	"Download CSV":                                  37,
+	"Download receipt":                              49,
+	"Edit":                                          92,
+	"Edit retroactively":                            94,
+	"End":                                           172,
+	"Error":                                         126,
+	"Event":                                         164,
+	"Expires":                                       89,
+	"From":                                          3,
+	"Holidays (one date yyyy-mm-dd per row)":        72,
+	"Hourly rate":                                   185,
+	"Hourly rates per shift name (optional, can be overridden in each shift)": 70,
+	"Hours": 59,
+	"Hours are the worked hours of approved takes, without no-shows and unpaid breaks.": 28,
+	"Join waitlist":         190,
+	"Keep event assignment": 167,
+	"Leave waitlist":        144,
+	"Limits per taker name. Leave empty for no limit. Shifts count towards the day, week and month in which they begin.": 80,
+	"Link Properties":                        160,
+	"Link expires":                           113,
+	"Location":                               66,
+	"Mark any shift as paid out":             152,
+	"Mark as paid out":                       53,
+	"Maximum hours per day":                  77,
+	"Maximum hours per month":                79,
+	"Maximum hours per week":                 78,
+	"Minimum rest between shifts (hours)":    76,
+	"My shifts":                              119,
+	"Name":                                   64,
+	"No paid shifts in this period.":         43,
+	"No past shifts.":                        26,
+	"No shifts are over in this date range.": 13,
+	"No shifts have been taken in this week or month.": 22,
+	"No shifts or events yet.":                         131,
+	"No shifts.":                                       50,
+	"No taker has overlapping shifts.":                 16,
+	"No upcoming shifts.":                              24,
+	"Not if overlaps are rejected or limits are set, because promoted people are not checked for them.": 83,
+	"Not paid out yet":               40,
+	"Note":                           87,
+	"Nothing has been paid out yet.": 63,
+	"Offer for handover":             196,
+	"Offer handover":                 141,
+	"Only shifts which you have taken or applied for": 117,
+	"Overlapping shift":    15,
+	"Pad":                  205,
+	"Paid out":             39,
+	"Paid out by":          57,
+	"Paid shifts taken by": 51,
+	"Past shifts":          25,
+	"Payout":               95,
+	"Payout ledger":        54,
+	"Payout receipt":       204,
+	"Payouts use the actual worked time instead of the planned time of the shift.": 200,
+	"Permissions":                             88,
+	"Place, date, signature of the payer":     207,
+	"Place, date, signature of the recipient": 208,
+	"Please use the full link.":               2,
+	"Quantity":                                163,
+	"Reason (optional)":                       202,
+	"Record actual worked time":               197,
+	"Record actual worked times of own takes": 101,
+	"Record time":                             138,
+	"Recurrence rule (RFC 5545 RRULE)":        179,
+	"Reject":                                  136,
+	"Reject application":                      203,
+	"Reject takes which overlap with another shift of the same taker (else just warn)": 81,
+	"Reliability":                    11,
+	"Repeat (optional)":              178,
+	"Report":                         55,
+	"Reset to planned time":          201,
+	"Save":                           84,
+	"Save attendance":                193,
+	"Save changes":                   161,
+	"Settings":                       121,
+	"Share":                          122,
+	"Shares":                         123,
+	"Shift":                          14,
+	"Shift Names (one name per row)": 67,
+	"Shift name":                     176,
+	"Shifts":                         58,
+	"Shifts of":                      27,
+	"Shortest rest":                  20,
+	"Show":                           5,
 	"Sorry, internal server error":   0,
 	"Sorry, not found":               1,
-	"Sum":                            12,
-	"Take":                           61,
-	"Take Shifts":                    33,
-	"Take and Apply":                 35,
-	"Take shift":                     80,
-	"Take shifts as":                 40,
-	"Taker":                          7,
-	"Taker names":                    39,
-	"These shifts have been marked as paid out for": 4,
-	"This is your customized share link":            24,
-	"This month":                                    50,
-	"This week":                                     49,
-	"Time":                                          5,
-	"Unknown event":                                 9,
-	"Unnamed Pad":                                   53,
-	"Upcoming Month":                                51,
-	"Upcoming Week":                                 52,
-	"View Shifts":                                   41,
-	"View taker contact":                            43,
-	"View taker name":                               42,
-	"applied":                                       66,
-	"do not assign to an event":                     75,
-	"hours":                                         11,
-	"ical Overlay":                                  21,
-	"last changed":                                  58,
-	"no shifts available":                           73,
-	"not paid out yet":                              78,
-	"not yet approved":                              15,
-	"paid":                                          10,
-	"paid out":                                      67,
+	"Status":                         31,
+	"Sum":                            41,
+	"Surcharge":                      48,
+	"Surcharges (one rule per row)":  71,
+	"Take":                           96,
+	"Take Shifts":                    153,
+	"Take and Apply":                 155,
+	"Take shift":                     191,
+	"Take shifts as":                 103,
+	"Taker":                          6,
+	"Taker names":                    157,
+	"Takes are not copied. Shifts which you are not allowed to create at the target date are skipped.": 168,
+	"Target day":  166,
+	"Target week": 165,
+	"The link will stop working immediately.":       149,
+	"There are no shifts to copy.":                  170,
+	"These shifts have been marked as paid out for": 44,
+	"These takes will be marked as not paid out. Takes which have been paid out again in a later payout are not changed.": 146,
+	"This and following shifts":          181,
+	"This is your customized share link": 85,
+	"This month":                         108,
+	"This shift":                         180,
+	"This week":                          107,
+	"Time":                               30,
+	"To":                                 4,
+	"Total paid out":                     206,
+	"Undo":                               62,
+	"Undo payout":                        145,
+	"Unknown event":                      45,
+	"Unnamed Pad":                        112,
+	"Unpaid break":                       133,
+	"Unpaid break in minutes":            175,
+	"Unpaid breaks (one rule per row)":   74,
+	"Upcoming Month":                     109,
+	"Upcoming Week":                      111,
+	"Upcoming shifts":                    23,
+	"View Shifts":                        159,
+	"View taker contact":                 105,
+	"View taker name":                    104,
+	"Wait":                               130,
+	"Waitlist":                           143,
+	"Waitlist: promote people who may take shifts directly to takers instead of applicants": 82,
+	"Warning":                 127,
+	"Week":                    19,
+	"Withdraw handover offer": 194,
+	"Withdraw offer":          140,
+	"Your take stays valid until someone accepts the offer.": 195,
+	"applied":                   35,
+	"approved":                  33,
+	"attended":                  7,
+	"do not assign to an event": 184,
+	"excused":                   9,
+	"handover offered":          139,
+	"hours":                     29,
+	"ical Overlay":              68,
+	"last changed":              125,
+	"min":                       134,
+	"no shifts available":       177,
+	"no-show":                   8,
+	"not paid out yet":          188,
+	"not yet approved":          52,
+	"offered":                   36,
+	"optional":                  186,
+	"paid":                      46,
+	"paid out":                  32,
+	"recurring":                 132,
+	"rejected":                  34,
+	"this link":                 90,
+	"undone":                    61,
+	"unknown":                   10,
 }
 
-var de_DEIndex = []uint32{ // 83 elements
+var de_DEIndex = []uint32{ // 210 elements
 	// Entry 0 - 1F
 	0x00000000, 0x0000001d, 0x00000033, 0x0000005b,
-	0x0000006d, 0x000000a1, 0x000000a6, 0x000000ae,
-	0x000000b3, 0x000000be, 0x000000d0, 0x000000d8,
-	0x000000e0, 0x000000e6, 0x000000f7, 0x0000010e,
-	0x00000124, 0x0000013d, 0x00000142, 0x0000015a,
-	0x00000163, 0x00000183, 0x00000190, 0x0000019a,
-	0x000001a2, 0x000001ca, 0x000001d8, 0x000001f2,
-	0x0000020c, 0x00000237, 0x00000244, 0x0000025c,
+	0x0000005f, 0x00000063, 0x0000006c, 0x00000071,
+	0x0000007a, 0x0000008b, 0x00000098, 0x000000a2,
+	0x000000b3, 0x00000169, 0x00000199, 0x000001a1,
+	0x000001ba, 0x000001e7, 0x000001f9, 0x00000206,
+	0x0000020c, 0x0000021f, 0x000002b0, 0x000002f5,
+	0x00000308, 0x00000323, 0x00000338, 0x00000355,
+	0x00000363, 0x000003d0, 0x000003d8, 0x000003dd,
 	// Entry 20 - 3F
-	0x00000267, 0x0000028d, 0x000002a6, 0x000002be,
-	0x000002e4, 0x000002f8, 0x00000315, 0x00000320,
-	0x00000326, 0x00000340, 0x00000353, 0x00000362,
-	0x00000373, 0x00000386, 0x00000392, 0x0000039a,
-	0x000003b0, 0x000003ba, 0x000003c6, 0x000003d3,
-	0x000003e3, 0x000003f2, 0x00000402, 0x00000413,
-	0x0000042b, 0x00000439, 0x00000440, 0x00000452,
-	0x00000459, 0x0000046b, 0x00000475, 0x0000047e,
+	0x000003e4, 0x000003ef, 0x000003fa, 0x00000404,
+	0x0000040d, 0x00000417, 0x00000429, 0x00000431,
+	0x0000043c, 0x00000452, 0x00000458, 0x000004d8,
+	0x00000506, 0x0000053a, 0x0000054c, 0x00000554,
+	0x0000056e, 0x00000577, 0x0000058e, 0x0000059f,
+	0x000005b6, 0x000005cc, 0x000005e5, 0x000005f5,
+	0x000005fd, 0x00000603, 0x00000612, 0x0000061c,
+	0x00000624, 0x0000062b, 0x00000640, 0x00000654,
 	// Entry 40 - 5F
-	0x00000489, 0x00000492, 0x000004bd, 0x000004c6,
-	0x000004d1, 0x000004d8, 0x000004dd, 0x00000502,
-	0x00000509, 0x00000511, 0x0000052b, 0x0000053c,
-	0x00000554, 0x0000055c, 0x00000572, 0x00000588,
-	0x0000059d, 0x000005b4, 0x000005c7,
-} // Size: 356 bytes
+	0x00000674, 0x00000679, 0x00000691, 0x0000069a,
+	0x000006ba, 0x000006c7, 0x000006d0, 0x00000729,
+	0x0000074b, 0x00000776, 0x00000880, 0x000008a9,
+	0x00000950, 0x0000097d, 0x00000998, 0x000009b5,
+	0x000009d2, 0x00000a4f, 0x00000abc, 0x00000b13,
+	0x00000b97, 0x00000ba1, 0x00000bc9, 0x00000bd7,
+	0x00000bdf, 0x00000bee, 0x00000bfa, 0x00000c06,
+	0x00000c20, 0x00000c2b, 0x00000c38, 0x00000c50,
+	// Entry 60 - 7F
+	0x00000c5b, 0x00000c65, 0x00000c6e, 0x00000c82,
+	0x00000ca1, 0x00000cbe, 0x00000cf8, 0x00000d03,
+	0x00000d1d, 0x00000d2c, 0x00000d3d, 0x00000d46,
+	0x00000d52, 0x00000d5f, 0x00000d6f, 0x00000d7e,
+	0x00000d8d, 0x00000d9d, 0x00000dae, 0x00000dc6,
+	0x00000de5, 0x00000dfa, 0x00000e34, 0x00000e53,
+	0x00000e63, 0x00000e6f, 0x00000e7d, 0x00000e84,
+	0x00000e8e, 0x00000e98, 0x00000eaa, 0x00000eb1,
+	// Entry 80 - 9F
+	0x00000eb9, 0x00000ec6, 0x00000ed8, 0x00000edf,
+	0x00000f0a, 0x00000f18, 0x00000f29, 0x00000f2e,
+	0x00000f37, 0x00000f40, 0x00000f56, 0x00000f64,
+	0x00000f78, 0x00000f8e, 0x00000fa1, 0x00000fb4,
+	0x00000fbf, 0x00000fd4, 0x00000ff3, 0x0000108e,
+	0x00001098, 0x000010ae, 0x000010d7, 0x000010f1,
+	0x0000111c, 0x00001142, 0x0000115b, 0x00001173,
+	0x00001199, 0x000011b6, 0x000011bc, 0x000011ef,
+	// Entry A0 - BF
+	0x00001202, 0x00001215, 0x0000122b, 0x00001241,
+	0x00001248, 0x0000124e, 0x00001258, 0x00001260,
+	0x0000127c, 0x000012ea, 0x000012fd, 0x00001323,
+	0x0000132a, 0x0000132f, 0x00001354, 0x0000135a,
+	0x00001376, 0x0000137e, 0x00001398, 0x000013af,
+	0x000013d3, 0x000013e1, 0x000013fe, 0x00001417,
+	0x00001428, 0x00001440, 0x0000144c, 0x00001455,
+	0x0000145d, 0x00001473, 0x00001488, 0x0000149b,
+	// Entry C0 - DF
+	0x000014b2, 0x000014c5, 0x000014db, 0x000014fa,
+	0x0000153b, 0x00001552, 0x00001575, 0x0000158b,
+	0x0000159f, 0x000015fa, 0x0000161a, 0x0000162b,
+	0x0000163e, 0x00001652, 0x00001656, 0x0000166b,
+	0x0000169c, 0x000016cd,
+} // Size: 864 bytes
 
-const de_DEData string = "" + // Size: 1479 bytes
+const de_DEData string = "" + // Size: 5837 bytes
 	"\x02Sorry, interner Serverfehler\x02Sorry, nicht gefunden\x02Bitte verwe" +
-	"nde den vollständigen Link.\x02Neues Pad anlegen\x02Diese Schichten wurd" +
-	"en als ausbezahlt markiert für\x02Zeit\x02Schicht\x02Name\x02Ausbezahlt" +
-	"\x02Unbekanntes Event\x02bezahlt\x02Stunden\x02Summe\x02Keine Schichten." +
-	"\x02Bezahlte Schichten von\x02noch nicht angenommen\x02Als ausbezahlt ma" +
-	"rkieren\x02Name\x02Beschreibung (Markdown)\x02Zeitzone\x02Schicht-Typen " +
-	"(einer pro Zeile)\x02ical-Overlay\x02Speichern\x02Zurück\x02Dies ist dei" +
-	"n gewünschter Freigabelink\x02Link kopieren\x02Dieses Pad administrieren" +
-	"\x02Dieses Pad administrieren\x02Schichten anlegen, bearbeiten und lösch" +
-	"en\x02Jede Schicht\x02Rückwirkend bearbeiten\x02Auszahlung\x02Jede Schic" +
-	"ht als ausgezahlt markieren\x02Für Schichten eintragen\x02Für Schichten " +
-	"bewerben\x02Für Schichten eintragen und bewerben\x02Deadline (optional)" +
-	"\x02Cron-Ausdruck, beispielweise\x02Jeder Name\x02Namen\x02Schichten übe" +
-	"rnehmen als\x02Schichten anzeigen\x02Namen anzeigen\x02Kontakt anzeigen" +
-	"\x02Link-Eigenschaften\x02Gültig bis\x02Hinweis\x02Freigabelink erzeugen" +
-	"\x02Abbrechen\x02Diese Woche\x02Dieser Monat\x02Kommender Monat\x02Komme" +
-	"nde Woche\x02Unbenanntes Pad\x02Link gültig bis\x02iCalendar-Link kopier" +
-	"en\x02Einstellungen\x02Teilen\x02zuletzt geändert\x02Fehler\x02Schichten" +
-	" anlegen\x02Eintragen\x02Bewerben\x02Bearbeiten\x02Löschen\x02Noch keine" +
-	" Schichten oder Veranstaltungen.\x02beworben\x02ausbezahlt\x02Beginn\x02" +
-	"Ende\x02Der Beginn muss vor dem Ende liegen.\x02Anzahl\x02Schicht\x02kei" +
-	"ne Schichten vorhanden\x02Schicht löschen\x02keinem Event zugeordnet\x02" +
-	"Kontakt\x02Änderungen speichern\x02noch nicht ausbezahlt\x02Auf Schicht " +
-	"bewerben\x02Für Schicht eintragen\x02Bewerbung annehmen"
+	"nde den vollständigen Link.\x02Von\x02Bis\x02Anzeigen\x02Name\x02anwesen" +
+	"d\x02nicht erschienen\x02entschuldigt\x02unbekannt\x02Zuverlässigkeit" +
+	"\x02Angenommene Eintragungen in vergangene Schichten, die im Zeitraum be" +
+	"ginnen. Die Zuverlässigkeit ist der Anteil der anwesenden an den anwesen" +
+	"den und nicht erschienenen Eintragungen.\x02In diesem Zeitraum sind kein" +
+	"e Schichten vorbei.\x02Schicht\x02Überschneidende Schicht\x02Niemand hat" +
+	" sich überschneidende Schichten.\x02Neues Pad anlegen\x02Vollster Tag" +
+	"\x02Woche\x02Kürzeste Ruhezeit\x02Alle Werte in Stunden. Schichten zähle" +
+	"n zu dem Tag, der Woche und dem Monat, in dem sie beginnen. Abgelehnte B" +
+	"ewerbungen werden nicht gezählt.\x02In dieser Woche und diesem Monat wur" +
+	"den keine Schichten übernommen.\x02Kommende Schichten\x02Keine kommenden" +
+	" Schichten.\x02Vergangene Schichten\x02Keine vergangenen Schichten.\x02S" +
+	"chichten von\x02Stunden sind die gearbeiteten Stunden angenommener Eintr" +
+	"agungen, ohne Nichterscheinen und unbezahlte Pausen.\x02Stunden\x02Zeit" +
+	"\x02Status\x02ausbezahlt\x02angenommen\x02abgelehnt\x02beworben\x02angeb" +
+	"oten\x02CSV herunterladen\x02Zurück\x02Ausbezahlt\x02Noch nicht ausbezah" +
+	"lt\x02Summe\x02Angenommene Eintragungen in bezahlte Schichten, die in di" +
+	"esem Zeitraum beginnen. Die Anzahl der Eintragungen steht in Klammern." +
+	"\x02Keine bezahlten Schichten in diesem Zeitraum.\x02Diese Schichten wur" +
+	"den als ausbezahlt markiert für\x02Unbekanntes Event\x02bezahlt\x02Tatsä" +
+	"chliche Arbeitszeit\x02Zuschlag\x02Quittung herunterladen\x02Keine Schic" +
+	"hten.\x02Bezahlte Schichten von\x02noch nicht angenommen\x02Als ausbezah" +
+	"lt markieren\x02Auszahlungsbuch\x02Bericht\x02Datum\x02Ausbezahlt von" +
+	"\x02Schichten\x02Stunden\x02Betrag\x02rückgängig gemacht\x02Rückgängig m" +
+	"achen\x02Bisher wurde nichts ausbezahlt.\x02Name\x02Beschreibung (Markdo" +
+	"wn)\x02Zeitzone\x02Schicht-Typen (einer pro Zeile)\x02ical-Overlay\x02Wä" +
+	"hrung\x02Stundensätze pro Schicht-Typ (optional, können in jeder Schicht" +
+	" überschrieben werden)\x02Zuschläge (eine Regel pro Zeile)\x02Feiertage " +
+	"(ein Datum yyyy-mm-dd pro Zeile)\x02Eine Zuschlagsregel besteht aus eine" +
+	"m Namen, einem Prozentsatz des Stundensatzes und optional Wochentagen (M" +
+	"on bis Sun), \x22holidays\x22 und einem Zeitfenster. Die Stunden werden " +
+	"in der Zeitzone des Pads aufgeteilt. Zuschläge werden auf den Auszahlung" +
+	"sseiten angezeigt.\x02Unbezahlte Pausen (eine Regel pro Zeile)\x02Eine P" +
+	"ausenregel legt die unbezahlte Mindestpause von Schichten fest, die läng" +
+	"er als die angegebene Dauer sind. Die Pause wird von den Stunden der Sch" +
+	"icht abgezogen.\x02Mindestruhezeit zwischen Schichten (Stunden)\x02Höchs" +
+	"tens Stunden pro Tag\x02Höchstens Stunden pro Woche\x02Höchstens Stunden" +
+	" pro Monat\x02Grenzen pro Name. Leer lassen für keine Grenze. Schichten " +
+	"zählen zu dem Tag, der Woche und dem Monat, in dem sie beginnen.\x02Eint" +
+	"ragungen ablehnen, die sich mit einer anderen Schicht derselben Person ü" +
+	"berschneiden (sonst nur warnen)\x02Warteliste: Personen, die sich eintra" +
+	"gen dürfen, direkt eintragen statt als Bewerbung\x02Nicht, wenn Überschn" +
+	"eidungen abgelehnt werden oder Grenzen gesetzt sind, weil nachrückende P" +
+	"ersonen nicht darauf geprüft werden.\x02Speichern\x02Dies ist dein gewün" +
+	"schter Freigabelink\x02Link kopieren\x02Hinweis\x02Berechtigungen\x02Gül" +
+	"tig bis\x02dieser Link\x02Dieses Pad administrieren\x02Bearbeiten\x02Jed" +
+	"e Schicht\x02Rückwirkend bearbeiten\x02Auszahlung\x02Eintragen\x02Bewerb" +
+	"en\x02Deadline (optional)\x02Eigene Eintragungen stornieren\x02Stornieru" +
+	"ngsfrist (optional)\x02Tatsächliche Arbeitszeiten eigener Eintragungen e" +
+	"rfassen\x02Jeder Name\x02Schichten übernehmen als\x02Namen anzeigen\x02K" +
+	"ontakt anzeigen\x02Löschen\x02Diese Woche\x02Dieser Monat\x02Kommender M" +
+	"onat\x02Woche kopieren\x02Kommende Woche\x02Unbenanntes Pad\x02Link gült" +
+	"ig bis\x02iCalendar-Link kopieren\x02Kalender für CalDAV-Programme\x02Ca" +
+	"lDAV-Link kopieren\x02Nur Schichten, für die du eingetragen oder beworbe" +
+	"n bist\x02Meinen iCalendar-Link kopieren\x02Meine Schichten\x02Anwesenhe" +
+	"it\x02Einstellungen\x02Teilen\x02Freigaben\x02Konflikte\x02zuletzt geänd" +
+	"ert\x02Fehler\x02Warnung\x02Tag kopieren\x02Schichten anlegen\x02Warten" +
+	"\x02Noch keine Schichten oder Veranstaltungen.\x02wiederkehrend\x02Unbez" +
+	"ahlte Pause\x02Min.\x02Annehmen\x02Ablehnen\x02Eintragung stornieren\x02" +
+	"Zeit erfassen\x02Übergabe angeboten\x02Angebot zurückziehen\x02Übergabe " +
+	"anbieten\x02Übergabe annehmen\x02Warteliste\x02Warteliste verlassen\x02A" +
+	"uszahlung rückgängig machen\x02Diese Eintragungen werden als nicht ausbe" +
+	"zahlt markiert. Eintragungen, die in einer späteren Auszahlung erneut au" +
+	"sbezahlt wurden, werden nicht geändert.\x02Abbrechen\x02Freigabelink lös" +
+	"chen\x02Der Link funktioniert sofort nicht mehr.\x02Dieses Pad administr" +
+	"ieren\x02Schichten anlegen, bearbeiten und löschen\x02Jede Schicht als a" +
+	"usgezahlt markieren\x02Für Schichten eintragen\x02Für Schichten bewerben" +
+	"\x02Für Schichten eintragen und bewerben\x02Cron-Ausdruck, beispielweise" +
+	"\x02Namen\x02Cron-Ausdruck oder Zeit vor Beginn, beispielsweise\x02Schic" +
+	"hten anzeigen\x02Link-Eigenschaften\x02Änderungen speichern\x02Freigabel" +
+	"ink erzeugen\x02Anzahl\x02Event\x02Zielwoche\x02Zieltag\x02Event-Zuordnu" +
+	"ng beibehalten\x02Eintragungen werden nicht kopiert. Schichten, die du a" +
+	"m Zieldatum nicht anlegen darfst, werden übersprungen.\x02Schichten kopi" +
+	"eren\x02Es gibt keine Schichten zum Kopieren.\x02Beginn\x02Ende\x02Der B" +
+	"eginn muss vor dem Ende liegen.\x02Pause\x02Unbezahlte Pause in Minuten" +
+	"\x02Schicht\x02keine Schichten vorhanden\x02Wiederholen (optional)\x02Wi" +
+	"ederholungsregel (RFC 5545 RRULE)\x02Diese Schicht\x02Diese und folgende" +
+	" Schichten\x02Alle Schichten der Serie\x02Schicht löschen\x02keinem Even" +
+	"t zugeordnet\x02Stundensatz\x02optional\x02Kontakt\x02noch nicht ausbeza" +
+	"hlt\x02Auf Schicht bewerben\x02Auf die Warteliste\x02Für Schicht eintrag" +
+	"en\x02Bewerbung annehmen\x02Anwesenheit speichern\x02Übergabeangebot zur" +
+	"ückziehen\x02Deine Eintragung bleibt gültig, bis jemand das Angebot ann" +
+	"immt.\x02Zur Übergabe anbieten\x02Tatsächliche Arbeitszeit erfassen\x02T" +
+	"atsächlicher Beginn\x02Tatsächliches Ende\x02Auszahlungen verwenden die " +
+	"tatsächliche Arbeitszeit statt der geplanten Zeit der Schicht.\x02Auf ge" +
+	"plante Zeit zurücksetzen\x02Grund (optional)\x02Bewerbung ablehnen\x02Au" +
+	"szahlungsquittung\x02Pad\x02Insgesamt ausgezahlt\x02Ort, Datum, Untersch" +
+	"rift der auszahlenden Person\x02Ort, Datum, Unterschrift der empfangende" +
+	"n Person"
 
-var en_USIndex = []uint32{ // 83 elements
+var en_USIndex = []uint32{ // 210 elements
 	// Entry 0 - 1F
 	0x00000000, 0x0000001d, 0x0000002e, 0x00000048,
-	0x00000057, 0x00000085, 0x0000008a, 0x00000090,
-	0x00000096, 0x0000009f, 0x000000ad, 0x000000b2,
-	0x000000b8, 0x000000bc, 0x000000c7, 0x000000dc,
-	0x000000ed, 0x000000fe, 0x00000103, 0x0000011a,
-	0x00000123, 0x00000142, 0x0000014f, 0x00000154,
-	0x00000159, 0x0000017c, 0x00000186, 0x0000019c,
-	0x000001b2, 0x000001d1, 0x000001db, 0x000001ee,
+	0x0000004d, 0x00000050, 0x00000055, 0x0000005b,
+	0x00000064, 0x0000006c, 0x00000074, 0x0000007c,
+	0x00000088, 0x0000011a, 0x00000141, 0x00000147,
+	0x00000159, 0x0000017a, 0x00000189, 0x00000195,
+	0x0000019a, 0x000001a8, 0x00000226, 0x00000257,
+	0x00000267, 0x0000027b, 0x00000287, 0x00000297,
+	0x000002a1, 0x000002f3, 0x000002f9, 0x000002fe,
 	// Entry 20 - 3F
-	0x000001f5, 0x00000210, 0x0000021c, 0x0000022d,
-	0x0000023c, 0x00000250, 0x00000269, 0x00000278,
-	0x00000284, 0x00000293, 0x0000029f, 0x000002af,
-	0x000002c2, 0x000002d2, 0x000002da, 0x000002df,
-	0x000002f1, 0x000002f8, 0x00000302, 0x0000030d,
-	0x0000031c, 0x0000032a, 0x00000336, 0x00000343,
-	0x00000352, 0x0000035b, 0x00000361, 0x0000036e,
-	0x00000374, 0x00000382, 0x00000387, 0x0000038d,
+	0x00000305, 0x0000030e, 0x00000317, 0x00000320,
+	0x00000328, 0x00000330, 0x0000033d, 0x00000342,
+	0x0000034b, 0x0000035c, 0x00000360, 0x000003c7,
+	0x000003e6, 0x00000414, 0x00000422, 0x00000427,
+	0x0000043a, 0x00000444, 0x00000455, 0x00000460,
+	0x00000475, 0x00000486, 0x00000497, 0x000004a5,
+	0x000004ac, 0x000004b1, 0x000004bd, 0x000004c4,
+	0x000004ca, 0x000004d1, 0x000004d8, 0x000004dd,
 	// Entry 40 - 5F
-	0x00000392, 0x00000399, 0x000003b2, 0x000003ba,
-	0x000003c3, 0x000003c9, 0x000003cd, 0x000003e7,
-	0x000003f0, 0x000003fb, 0x0000040f, 0x0000041c,
-	0x00000436, 0x0000043e, 0x0000044b, 0x0000045c,
-	0x0000046c, 0x00000477, 0x00000484,
-} // Size: 356 bytes
+	0x000004fc, 0x00000501, 0x00000518, 0x00000521,
+	0x00000540, 0x0000054d, 0x00000556, 0x0000059e,
+	0x000005bc, 0x000005e3, 0x000006c2, 0x000006e3,
+	0x00000773, 0x00000797, 0x000007ad, 0x000007c4,
+	0x000007dc, 0x0000084f, 0x000008a0, 0x000008f6,
+	0x00000958, 0x0000095d, 0x00000980, 0x0000098a,
+	0x0000098f, 0x0000099b, 0x000009a3, 0x000009ad,
+	0x000009c3, 0x000009c8, 0x000009d2, 0x000009e5,
+	// Entry 60 - 7F
+	0x000009ec, 0x000009f1, 0x000009f7, 0x00000a0b,
+	0x00000a1c, 0x00000a37, 0x00000a5f, 0x00000a6e,
+	0x00000a7d, 0x00000a8d, 0x00000aa0, 0x00000aa7,
+	0x00000ab1, 0x00000abc, 0x00000acb, 0x00000ad5,
+	0x00000ae3, 0x00000aef, 0x00000afc, 0x00000b0b,
+	0x00000b32, 0x00000b3e, 0x00000b6e, 0x00000b80,
+	0x00000b8a, 0x00000b95, 0x00000b9e, 0x00000ba4,
+	0x00000bab, 0x00000bb5, 0x00000bc2, 0x00000bc8,
+	// Entry 80 - 9F
+	0x00000bd0, 0x00000bd9, 0x00000be7, 0x00000bec,
+	0x00000c05, 0x00000c0f, 0x00000c1c, 0x00000c20,
+	0x00000c28, 0x00000c2f, 0x00000c3b, 0x00000c47,
+	0x00000c58, 0x00000c67, 0x00000c76, 0x00000c86,
+	0x00000c8f, 0x00000c9e, 0x00000caa, 0x00000d1e,
+	0x00000d25, 0x00000d37, 0x00000d5f, 0x00000d75,
+	0x00000d94, 0x00000daf, 0x00000dbb, 0x00000dcc,
+	0x00000ddb, 0x00000df4, 0x00000e00, 0x00000e2e,
+	// Entry A0 - BF
+	0x00000e3a, 0x00000e4a, 0x00000e57, 0x00000e69,
+	0x00000e72, 0x00000e78, 0x00000e84, 0x00000e8f,
+	0x00000ea5, 0x00000f06, 0x00000f12, 0x00000f2f,
+	0x00000f35, 0x00000f39, 0x00000f53, 0x00000f59,
+	0x00000f71, 0x00000f7c, 0x00000f90, 0x00000fa2,
+	0x00000fc3, 0x00000fce, 0x00000fe8, 0x00001001,
+	0x0000100e, 0x00001028, 0x00001034, 0x0000103d,
+	0x00001045, 0x00001056, 0x00001066, 0x00001074,
+	// Entry C0 - DF
+	0x0000107f, 0x0000108c, 0x0000109c, 0x000010b4,
+	0x000010eb, 0x000010fe, 0x00001118, 0x00001125,
+	0x00001130, 0x0000117d, 0x00001193, 0x000011a5,
+	0x000011b8, 0x000011c7, 0x000011cb, 0x000011da,
+	0x000011fe, 0x00001226,
+} // Size: 864 bytes
 
-const en_USData string = "" + // Size: 1156 bytes
+const en_USData string = "" + // Size: 4646 bytes
 	"\x02Sorry, internal server error\x02Sorry, not found\x02Please use the f" +
-	"ull link.\x02Create new Pad\x02These shifts have been marked as paid out" +
-	" for\x02Time\x02Shift\x02Taker\x02Paid out\x02Unknown event\x02paid\x02h" +
-	"ours\x02Sum\x02No shifts.\x02Paid shifts taken by\x02not yet approved" +
-	"\x02Mark as paid out\x02Name\x02Description (Markdown)\x02Location\x02Sh" +
-	"ift Names (one name per row)\x02ical Overlay\x02Save\x02Back\x02This is " +
-	"your customized share link\x02Copy link\x02Administrate this Pad\x02Admi" +
-	"nistrate this pad\x02Create, Edit and Delete Shifts\x02Any shift\x02Edit" +
-	" retroactively\x02Payout\x02Mark any shift as paid out\x02Take Shifts" +
-	"\x02Apply for Shifts\x02Take and Apply\x02Deadline (optional)\x02Cron ex" +
-	"pression, example\x02Any taker name\x02Taker names\x02Take shifts as\x02" +
-	"View Shifts\x02View taker name\x02View taker contact\x02Link Properties" +
-	"\x02Expires\x02Note\x02Create share link\x02Cancel\x02This week\x02This " +
-	"month\x02Upcoming Month\x02Upcoming Week\x02Unnamed Pad\x02Link expires" +
-	"\x02Copy iCalendar\x02Settings\x02Share\x02last changed\x02Error\x02Crea" +
-	"te shifts\x02Take\x02Apply\x02Edit\x02Delete\x02No shifts or events yet." +
-	"\x02applied\x02paid out\x02Begin\x02End\x02Begin must be before end.\x02" +
-	"Quantity\x02Shift name\x02no shifts available\x02Delete shift\x02do not " +
-	"assign to an event\x02Contact\x02Save changes\x02not paid out yet\x02App" +
-	"ly for shift\x02Take shift\x02Approve take"
+	"ull link.\x02From\x02To\x02Show\x02Taker\x02attended\x02no-show\x02excus" +
+	"ed\x02unknown\x02Reliability\x02Approved takes of shifts which are over " +
+	"and begin in the date range. Reliability is the share of attended takes " +
+	"among attended and no-show takes.\x02No shifts are over in this date ran" +
+	"ge.\x02Shift\x02Overlapping shift\x02No taker has overlapping shifts." +
+	"\x02Create new Pad\x02Busiest day\x02Week\x02Shortest rest\x02All values" +
+	" in hours. Shifts count towards the day, week and month in which they be" +
+	"gin. Rejected applications are not counted.\x02No shifts have been taken" +
+	" in this week or month.\x02Upcoming shifts\x02No upcoming shifts.\x02Pas" +
+	"t shifts\x02No past shifts.\x02Shifts of\x02Hours are the worked hours o" +
+	"f approved takes, without no-shows and unpaid breaks.\x02hours\x02Time" +
+	"\x02Status\x02paid out\x02approved\x02rejected\x02applied\x02offered\x02" +
+	"Download CSV\x02Back\x02Paid out\x02Not paid out yet\x02Sum\x02Approved " +
+	"takes of paid shifts which begin in this period. The number of takes is " +
+	"given in parentheses.\x02No paid shifts in this period.\x02These shifts " +
+	"have been marked as paid out for\x02Unknown event\x02paid\x02Actual work" +
+	"ed time\x02Surcharge\x02Download receipt\x02No shifts.\x02Paid shifts ta" +
+	"ken by\x02not yet approved\x02Mark as paid out\x02Payout ledger\x02Repor" +
+	"t\x02Date\x02Paid out by\x02Shifts\x02Hours\x02Amount\x02undone\x02Undo" +
+	"\x02Nothing has been paid out yet.\x02Name\x02Description (Markdown)\x02" +
+	"Location\x02Shift Names (one name per row)\x02ical Overlay\x02Currency" +
+	"\x02Hourly rates per shift name (optional, can be overridden in each shi" +
+	"ft)\x02Surcharges (one rule per row)\x02Holidays (one date yyyy-mm-dd pe" +
+	"r row)\x02A surcharge rule consists of a name, a percentage of the hourl" +
+	"y rate and optionally weekdays (Mon to Sun), \x22holidays\x22 and a time" +
+	" window. Hours are split in the location of the pad. Surcharges are show" +
+	"n on the payout pages.\x02Unpaid breaks (one rule per row)\x02A break ru" +
+	"le sets the minimum unpaid break of shifts which are longer than the giv" +
+	"en length. The break is deducted from the hours of the shift.\x02Minimum" +
+	" rest between shifts (hours)\x02Maximum hours per day\x02Maximum hours p" +
+	"er week\x02Maximum hours per month\x02Limits per taker name. Leave empty" +
+	" for no limit. Shifts count towards the day, week and month in which the" +
+	"y begin.\x02Reject takes which overlap with another shift of the same ta" +
+	"ker (else just warn)\x02Waitlist: promote people who may take shifts dir" +
+	"ectly to takers instead of applicants\x02Not if overlaps are rejected or" +
+	" limits are set, because promoted people are not checked for them.\x02Sa" +
+	"ve\x02This is your customized share link\x02Copy link\x02Note\x02Permiss" +
+	"ions\x02Expires\x02this link\x02Administrate this pad\x02Edit\x02Any shi" +
+	"ft\x02Edit retroactively\x02Payout\x02Take\x02Apply\x02Deadline (optiona" +
+	"l)\x02Cancel own takes\x02Cancel deadline (optional)\x02Record actual wo" +
+	"rked times of own takes\x02Any taker name\x02Take shifts as\x02View take" +
+	"r name\x02View taker contact\x02Delete\x02This week\x02This month\x02Upc" +
+	"oming Month\x02Copy week\x02Upcoming Week\x02Unnamed Pad\x02Link expires" +
+	"\x02Copy iCalendar\x02Calendar collection for CalDAV clients\x02Copy Cal" +
+	"DAV\x02Only shifts which you have taken or applied for\x02Copy my iCalen" +
+	"dar\x02My shifts\x02Attendance\x02Settings\x02Share\x02Shares\x02Conflic" +
+	"ts\x02last changed\x02Error\x02Warning\x02Copy day\x02Create shifts\x02W" +
+	"ait\x02No shifts or events yet.\x02recurring\x02Unpaid break\x02min\x02A" +
+	"pprove\x02Reject\x02Cancel take\x02Record time\x02handover offered\x02Wi" +
+	"thdraw offer\x02Offer handover\x02Accept handover\x02Waitlist\x02Leave w" +
+	"aitlist\x02Undo payout\x02These takes will be marked as not paid out. Ta" +
+	"kes which have been paid out again in a later payout are not changed." +
+	"\x02Cancel\x02Delete share link\x02The link will stop working immediatel" +
+	"y.\x02Administrate this Pad\x02Create, Edit and Delete Shifts\x02Mark an" +
+	"y shift as paid out\x02Take Shifts\x02Apply for Shifts\x02Take and Apply" +
+	"\x02Cron expression, example\x02Taker names\x02Cron expression or time b" +
+	"efore begin, example\x02View Shifts\x02Link Properties\x02Save changes" +
+	"\x02Create share link\x02Quantity\x02Event\x02Target week\x02Target day" +
+	"\x02Keep event assignment\x02Takes are not copied. Shifts which you are " +
+	"not allowed to create at the target date are skipped.\x02Copy shifts\x02" +
+	"There are no shifts to copy.\x02Begin\x02End\x02Begin must be before end" +
+	".\x02Break\x02Unpaid break in minutes\x02Shift name\x02no shifts availab" +
+	"le\x02Repeat (optional)\x02Recurrence rule (RFC 5545 RRULE)\x02This shif" +
+	"t\x02This and following shifts\x02All shifts of the series\x02Delete shi" +
+	"ft\x02do not assign to an event\x02Hourly rate\x02optional\x02Contact" +
+	"\x02not paid out yet\x02Apply for shift\x02Join waitlist\x02Take shift" +
+	"\x02Approve take\x02Save attendance\x02Withdraw handover offer\x02Your t" +
+	"ake stays valid until someone accepts the offer.\x02Offer for handover" +
+	"\x02Record actual worked time\x02Actual begin\x02Actual end\x02Payouts u" +
+	"se the actual worked time instead of the planned time of the shift.\x02R" +
+	"eset to planned time\x02Reason (optional)\x02Reject application\x02Payou" +
+	"t receipt\x02Pad\x02Total paid out\x02Place, date, signature of the paye" +
+	"r\x02Place, date, signature of the recipient"
 
-	// Total table size 3347 bytes (3KiB); checksum: 5BCE7CA5
+	// Total table size 12211 bytes (11KiB); checksum: 419413CA
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -28,15 +29,21 @@ func dateTimeRef(t, reference time.Time) string {
 	return t.Format("2. Jan 2006 15:04")
 }
 
+// FmtDateTimeRange formats a time range. The end contains the day only if it differs from the day of begin.
+func FmtDateTimeRange(begin, end time.Time) string {
+	return fmt.Sprintf("%s – %s", begin.Format("2. Jan 2006 15:04"), dateTimeRef(end, begin))
+}
+
 func parse(fn ...string) *template.Template {
 	return template.Must(template.New(fn[0]).Funcs(template.FuncMap{
+		"Contains": func(elems []string, s string) bool {
+			return slices.Contains(elems, s)
+		},
 		"FmtDate": func(t time.Time) string {
 			return t.Format("Monday 2. Jan 2006")
 		},
-		"FmtDateTimeRef": dateTimeRef,
-		"FmtDateTimeRange": func(begin, end time.Time) string {
-			return fmt.Sprintf("%s – %s", begin.Format("2. Jan 2006 15:04"), dateTimeRef(end, begin)) // begin always contains day, end contains day only if it differs
-		},
+		"FmtDateTimeRef":   dateTimeRef,
+		"FmtDateTimeRange": FmtDateTimeRange,
 		"FmtDateTimeRangeRef": func(begin, end, reference time.Time) string {
 			return fmt.Sprintf("%s – %s", dateTimeRef(begin, reference), dateTimeRef(end, begin)) // end's reference is not $reference, but begin
 		},
@@ -60,6 +67,13 @@ func parse(fn ...string) *template.Template {
 				Shift: shift,
 			}
 		},
+		"MakeMonthShiftsData": func(lang Lang, pad shiftpad.AuthPad, months []shiftpad.MonthShifts) MonthShiftsData {
+			return MonthShiftsData{
+				Lang:   lang,
+				Pad:    pad,
+				Months: months,
+			}
+		},
 		"Markdown": func(input string) template.HTML {
 			return template.HTML(md.RenderToString([]byte(input)))
 		},
@@ -80,20 +94,38 @@ var (
 	ErrInternalServerError = parse("layout.html", "err-internal-server-error.html")
 	ErrNotFound            = parse("layout.html", "err-not-found.html")
 	Index                  = parse("layout.html", "index.html")
+	PadAttendance          = parse("layout.html", "pad.html", "pad-attendance.html")
+	PadConflicts           = parse("layout.html", "pad.html", "pad-conflicts.html")
 	PadCreate              = parse("layout.html", "pad-create.html")
+	PadHours               = parse("layout.html", "pad.html", "pad-hours.html")
+	PadMyShifts            = parse("layout.html", "pad.html", "pad-my-shifts.html")
 	PadPayout              = parse("layout.html", "pad.html", "pad-payout.html")
+	PadPayoutReport        = parse("layout.html", "pad.html", "pad-payout-report.html")
 	PadPayoutTaker         = parse("layout.html", "pad.html", "pad-payout-taker.html")
 	PadPayoutTakerResult   = parse("layout.html", "pad.html", "pad-payout-taker-result.html")
+	PadPayouts             = parse("layout.html", "pad.html", "pad-payouts.html")
 	PadSettings            = parse("layout.html", "pad.html", "pad-settings.html")
-	PadShare               = parse("layout.html", "pad.html", "pad-share.html")
+	PadShare               = parse("layout.html", "pad.html", "share-form.html", "pad-share.html")
 	PadShareResult         = parse("layout.html", "pad.html", "pad-share-result.html")
+	PadShares              = parse("layout.html", "pad.html", "pad-shares.html")
 	PadViewMonth           = parse("layout.html", "pad.html", "pad-view-month.html")
 	PadViewWeek            = parse("layout.html", "pad.html", "pad-view-week.html")
+	PayoutUndo             = parse("layout.html", "pad.html", "payout-undo.html")
+	ShareDelete            = parse("layout.html", "pad.html", "share-delete.html")
+	ShareEdit              = parse("layout.html", "pad.html", "share-form.html", "share-edit.html")
+	ShiftCopy              = parse("layout.html", "pad.html", "shift-copy.html")
 	ShiftCreate            = parse("layout.html", "pad.html", "shift-create.html")
 	ShiftDelete            = parse("layout.html", "pad.html", "shift-delete.html")
 	ShiftEdit              = parse("layout.html", "pad.html", "shift-edit.html")
 	ShiftTake              = parse("layout.html", "pad.html", "shift-take.html")
+	TakeAccept             = parse("layout.html", "pad.html", "take-accept.html")
 	TakeApprove            = parse("layout.html", "pad.html", "take-approve.html")
+	TakeAttendance         = parse("layout.html", "pad.html", "take-attendance.html")
+	TakeCancel             = parse("layout.html", "pad.html", "take-cancel.html")
+	TakeOffer              = parse("layout.html", "pad.html", "take-offer.html")
+	TakeRecord             = parse("layout.html", "pad.html", "take-record.html")
+	TakeReject             = parse("layout.html", "pad.html", "take-reject.html")
+	WaiterLeave            = parse("layout.html", "pad.html", "waiter-leave.html")
 )
 
 type LayoutData struct {
@@ -110,23 +142,71 @@ type PadData struct {
 	LayoutData
 	ActiveTab string
 	Errors    []string
+	Warnings  []string
 	Pad       shiftpad.AuthPad
 }
 
+type PadConflictsData struct {
+	PadData
+	Conflicts []shiftpad.Conflict
+}
+
+type PadAttendanceData struct {
+	PadData
+	From        time.Time
+	To          time.Time
+	Reliability []shiftpad.Reliability
+}
+
+type PadHoursData struct {
+	PadData
+	Hours   []shiftpad.TakerHours
+	ISOWeek string
+	Month   time.Time
+}
+
+type PadMyShiftsData struct {
+	PadData
+	Upcoming []shiftpad.MonthShifts
+	Past     []shiftpad.MonthShifts // most recent first
+}
+
 type PadPayoutData struct {
 	PadData
 	TakerNames []string
 }
 
+type PadPayoutReportData struct {
+	PadData
+	From   time.Time
+	To     time.Time // inclusive
+	Report shiftpad.PayoutReport
+}
+
 type PadPayoutTakerData struct {
 	PadData
-	Name   string
-	Events []shiftpad.Event
+	Name           string
+	Events         []shiftpad.Event
+	Open           shiftpad.Payout // takes which can be paid out
+	OpenSurcharges []shiftpad.SurchargeHours
 }
 
 type PadPayoutTakerResultData struct {
 	PadPayoutTakerData
-	SumHours float64
+	PayoutID      int // zero if not given in the url query
+	SumAmount     float64
+	SumHours      float64
+	SumSurcharges []shiftpad.SurchargeHours
+}
+
+type PadPayoutsData struct {
+	PadData
+	Payouts []shiftpad.Payout
+}
+
+type PayoutUndoData struct {
+	PadData
+	Payout shiftpad.Payout
 }
 
 type PadSettingsData struct {
@@ -135,8 +215,10 @@ type PadSettingsData struct {
 	Locations []string
 }
 
+// PadShareData is used for creating and editing shares. Share contains the initial form values.
 type PadShareData struct {
 	PadData
+	Share shiftpad.Share
 }
 
 type PadShareResultData struct {
@@ -145,6 +227,11 @@ type PadShareResultData struct {
 	Link string
 }
 
+type PadSharesData struct {
+	PadData
+	Shares []shiftpad.Share
+}
+
 type PadViewMonthData struct {
 	PadData
 	Month         string // yyyy-mm
@@ -160,6 +247,8 @@ type PadViewMonthData struct {
 type PadViewWeekData struct {
 	PadData
 	ISOWeek      string // yyyy-Www
+	Year         int
+	Week         int
 	Days         []*shiftpad.Day
 	EarlierYear  int
 	EarlierWeek  int
@@ -169,6 +258,14 @@ type PadViewWeekData struct {
 	UpcomingWeek int
 }
 
+type TakeAcceptData struct {
+	PadData
+	Day        shiftpad.Day
+	Shift      *shiftpad.Shift
+	Take       shiftpad.Take
+	TakerNames []string
+}
+
 type TakeApproveData struct {
 	PadData
 	Day   shiftpad.Day
@@ -176,6 +273,50 @@ type TakeApproveData struct {
 	Take  shiftpad.Take
 }
 
+type TakeAttendanceData struct {
+	PadData
+	Attendances []shiftpad.Attendance
+	Day         shiftpad.Day
+	Shift       *shiftpad.Shift
+	Take        shiftpad.Take
+}
+
+type TakeCancelData struct {
+	PadData
+	Day   shiftpad.Day
+	Shift *shiftpad.Shift
+	Take  shiftpad.Take
+}
+
+type TakeOfferData struct {
+	PadData
+	Day      shiftpad.Day
+	Shift    *shiftpad.Shift
+	Take     shiftpad.Take
+	Withdraw bool // else offer
+}
+
+type TakeRecordData struct {
+	PadData
+	Error string
+	Shift *shiftpad.Shift
+	Take  shiftpad.Take
+}
+
+type TakeRejectData struct {
+	PadData
+	Day   shiftpad.Day
+	Shift *shiftpad.Shift
+	Take  shiftpad.Take
+}
+
+// for subtemplate "month-shifts"
+type MonthShiftsData struct {
+	Lang
+	Pad    shiftpad.AuthPad
+	Months []shiftpad.MonthShifts
+}
+
 // for subtemplate "shift-cells"
 type ShiftCellsData struct {
 	Lang
@@ -184,6 +325,27 @@ type ShiftCellsData struct {
 	Shift *shiftpad.Shift
 }
 
+type ShareDeleteData struct {
+	PadData
+	Share shiftpad.Share
+}
+
+// ShiftCopyData is used for copying the shifts of a week or a day. Target is the initial form value.
+type ShiftCopyData struct {
+	PadData
+	Begin     time.Time
+	End       time.Time // exclusive
+	Week      bool      // else day
+	Shifts    []shiftpad.Shift
+	HasEvents bool
+	Target    string // yyyy-Www or yyyy-mm-dd
+}
+
+// Last returns the last day of the copied timespan.
+func (data ShiftCopyData) Last() time.Time {
+	return data.End.AddDate(0, 0, -1)
+}
+
 type ShiftCreateData struct {
 	PadData
 	Day      shiftpad.Day
@@ -212,7 +374,15 @@ type ShiftEditData struct {
 type ShiftTakeData struct {
 	PadData
 	Apply      bool
+	Wait       bool
 	Day        shiftpad.Day
 	Shift      *shiftpad.Shift
 	TakerNames []string
 }
+
+type WaiterLeaveData struct {
+	PadData
+	Day    shiftpad.Day
+	Shift  *shiftpad.Shift
+	Waiter shiftpad.Waiter
+}
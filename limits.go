@@ -0,0 +1,137 @@
+package shiftpad
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Limits are pad-level rules for the working hours of each taker name. Zero values mean no limit.
+// Shifts are attributed to the day, week and month in which they begin.
+type Limits struct {
+	MinRest  float64 // hours between the end of a shift and the begin of the next shift
+	MaxDay   float64 // hours per calendar day
+	MaxWeek  float64 // hours per ISO week
+	MaxMonth float64 // hours per calendar month
+}
+
+func (limits Limits) Any() bool {
+	return limits.MinRest > 0 || limits.MaxDay > 0 || limits.MaxWeek > 0 || limits.MaxMonth > 0
+}
+
+// Check returns a message for each limit which is violated if name takes shift in addition to others.
+// Others are usually the result of Repository.GetTakesByTaker. Shifts in which name has only rejected takes are ignored.
+func (limits Limits) Check(shift Shift, name string, others []Shift) []string {
+	if !limits.Any() {
+		return nil
+	}
+
+	var taken = []Shift{shift}
+	for _, other := range others {
+		if other.ID != shift.ID && other.HasTaker(name) {
+			taken = append(taken, other)
+		}
+	}
+
+	var msgs []string
+	if limits.MinRest > 0 {
+		for _, other := range taken[1:] {
+			var rest time.Duration
+			switch {
+			case !other.End.After(shift.Begin):
+				rest = shift.Begin.Sub(other.End)
+			case !shift.End.After(other.Begin):
+				rest = other.Begin.Sub(shift.End)
+			}
+			if rest.Hours() < limits.MinRest {
+				msgs = append(msgs, fmt.Sprintf("%s would have less than %s hours of rest between %s at %s and this shift", name, fmtHours(limits.MinRest), other, other.Begin.Format("2006-01-02 15:04")))
+			}
+		}
+	}
+
+	begin := shift.Begin
+	day := time.Date(begin.Year(), begin.Month(), begin.Day(), 0, 0, 0, 0, begin.Location())
+	year, weekNumber := begin.ISOWeek()
+	week := WeekBegin(year, weekNumber, begin.Location())
+	month := time.Date(begin.Year(), begin.Month(), 1, 0, 0, 0, 0, begin.Location())
+	if limits.MaxDay > 0 {
+		if hours := sumHours(taken, day, day.AddDate(0, 0, 1)); hours > limits.MaxDay {
+			msgs = append(msgs, fmt.Sprintf("%s would work %s hours on %s, the limit is %s", name, fmtHours(hours), day.Format("2006-01-02"), fmtHours(limits.MaxDay)))
+		}
+	}
+	if limits.MaxWeek > 0 {
+		if hours := sumHours(taken, week, week.AddDate(0, 0, 7)); hours > limits.MaxWeek {
+			msgs = append(msgs, fmt.Sprintf("%s would work %s hours in the week of %s, the limit is %s", name, fmtHours(hours), week.Format("2006-01-02"), fmtHours(limits.MaxWeek)))
+		}
+	}
+	if limits.MaxMonth > 0 {
+		if hours := sumHours(taken, month, month.AddDate(0, 1, 0)); hours > limits.MaxMonth {
+			msgs = append(msgs, fmt.Sprintf("%s would work %s hours in %s, the limit is %s", name, fmtHours(hours), month.Format("2006-01"), fmtHours(limits.MaxMonth)))
+		}
+	}
+	return msgs
+}
+
+// TakerHours summarizes the hours of a taker name in a week and in the month in which the week begins.
+type TakerHours struct {
+	Name    string
+	MaxDay  float64 // hours of the busiest day of the week
+	Week    float64
+	Month   float64
+	MinRest float64 // shortest rest between two shifts which begin in the week, or -1 if there are less than two shifts
+}
+
+// SummarizeHours returns the hours of each taker name in the given shifts, sorted by name. Rejected takes are ignored.
+// The shifts should contain at least the week which begins at weekBegin and the month in which it begins.
+func SummarizeHours(shifts []Shift, weekBegin time.Time) []TakerHours {
+	weekEnd := weekBegin.AddDate(0, 0, 7)
+	month := time.Date(weekBegin.Year(), weekBegin.Month(), 1, 0, 0, 0, 0, weekBegin.Location())
+
+	var result []TakerHours
+	for name, shifts := range shiftsByTaker(shifts) {
+		sortShifts(shifts)
+		var th = TakerHours{
+			Name:    name,
+			Week:    sumHours(shifts, weekBegin, weekEnd),
+			Month:   sumHours(shifts, month, month.AddDate(0, 1, 0)),
+			MinRest: -1,
+		}
+		for day := weekBegin; day.Before(weekEnd); day = day.AddDate(0, 0, 1) {
+			th.MaxDay = max(th.MaxDay, sumHours(shifts, day, day.AddDate(0, 0, 1)))
+		}
+		var prev *Shift
+		for i, shift := range shifts {
+			if shift.Begin.Before(weekBegin) || !shift.Begin.Before(weekEnd) {
+				continue
+			}
+			if prev != nil {
+				rest := max(shift.Begin.Sub(prev.End).Hours(), 0)
+				if th.MinRest < 0 || rest < th.MinRest {
+					th.MinRest = rest
+				}
+			}
+			prev = &shifts[i]
+		}
+		result = append(result, th)
+	}
+	slices.SortFunc(result, func(a, b TakerHours) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return result
+}
+
+func fmtHours(hours float64) string {
+	return fmt.Sprintf("%.4g", hours)
+}
+
+// sumHours returns the sum of the hours of the shifts which begin in [from, to).
+func sumHours(shifts []Shift, from, to time.Time) float64 {
+	var sum float64
+	for _, shift := range shifts {
+		if !shift.Begin.Before(from) && shift.Begin.Before(to) {
+			sum += shift.Hours()
+		}
+	}
+	return sum
+}
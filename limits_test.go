@@ -0,0 +1,58 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimitsCheck(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC) // 2025-03-03 is a Monday
+	}
+	others := []Shift{
+		{ID: 1, Name: "a", Begin: at(3, 8), End: at(3, 14), Takes: []Take{{Name: "alice", Approved: true}}},
+		{ID: 2, Name: "a", Begin: at(4, 8), End: at(4, 16), Takes: []Take{{Name: "alice", Approved: true}}},
+		{ID: 3, Name: "a", Begin: at(5, 8), End: at(5, 16), Takes: []Take{{Name: "alice", Rejected: true}}},
+	}
+
+	tests := []struct {
+		limits Limits
+		shift  Shift
+		want   int
+	}{
+		{Limits{}, Shift{Begin: at(3, 14), End: at(3, 23)}, 0},
+		{Limits{MinRest: 11}, Shift{Begin: at(3, 20), End: at(3, 23)}, 2},      // 6h rest after shift 1, 9h before shift 2
+		{Limits{MinRest: 11}, Shift{Begin: at(5, 8), End: at(5, 16)}, 0},       // 16h rest after shift 2, rejected take is ignored
+		{Limits{MaxDay: 10}, Shift{Begin: at(3, 15), End: at(3, 20)}, 1},       // 6h + 5h
+		{Limits{MaxDay: 10}, Shift{ID: 1, Begin: at(3, 8), End: at(3, 18)}, 0}, // editing shift 1
+		{Limits{MaxWeek: 20}, Shift{Begin: at(6, 8), End: at(6, 16)}, 1},       // 6h + 8h + 8h
+		{Limits{MaxWeek: 20}, Shift{Begin: at(10, 8), End: at(10, 16)}, 0},     // next week
+		{Limits{MaxMonth: 20, MaxWeek: 100}, Shift{Begin: at(20, 8), End: at(20, 16)}, 1},
+	}
+	for i, test := range tests {
+		if got := test.limits.Check(test.shift, "alice", others); len(got) != test.want {
+			t.Fatalf("test %d: got %d violations, want %d: %v", i, len(got), test.want, got)
+		}
+	}
+}
+
+func TestSummarizeHours(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+	shifts := []Shift{
+		{ID: 1, Begin: at(3, 8), End: at(3, 14), Takes: []Take{{Name: "alice"}, {Name: "bob"}}},
+		{ID: 2, Begin: at(3, 20), End: at(3, 22), Takes: []Take{{Name: "alice"}}},
+		{ID: 3, Begin: at(20, 8), End: at(20, 16), Takes: []Take{{Name: "alice"}}},
+	}
+	got := SummarizeHours(shifts, at(3, 0))
+	if len(got) != 2 {
+		t.Fatalf("got %d takers, want 2", len(got))
+	}
+	if alice := got[0]; alice.Name != "alice" || alice.MaxDay != 8 || alice.Week != 8 || alice.Month != 16 || alice.MinRest != 6 {
+		t.Fatalf("got %+v", alice)
+	}
+	if bob := got[1]; bob.MinRest != -1 {
+		t.Fatalf("got %+v", bob)
+	}
+}
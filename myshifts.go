@@ -0,0 +1,30 @@
+package shiftpad
+
+import "time"
+
+// MonthShifts are consecutive shifts which begin in the same month.
+type MonthShifts struct {
+	Month  time.Time // first day of the month
+	Hours  float64   // worked hours of the approved takes, excluding no-shows
+	Shifts []Shift
+}
+
+// GroupByMonth groups consecutive shifts by the month in which they begin in the given location.
+func GroupByMonth(shifts []Shift, loc *time.Location) []MonthShifts {
+	var result []MonthShifts
+	for _, shift := range shifts {
+		begin := shift.Begin.In(loc)
+		month := time.Date(begin.Year(), begin.Month(), 1, 0, 0, 0, 0, loc)
+		if len(result) == 0 || !result[len(result)-1].Month.Equal(month) {
+			result = append(result, MonthShifts{Month: month})
+		}
+		last := &result[len(result)-1]
+		last.Shifts = append(last.Shifts, shift)
+		for _, take := range shift.Takes {
+			if take.Approved && take.Attendance != NoShow {
+				last.Hours += shift.Worked(take).Hours()
+			}
+		}
+	}
+	return result
+}
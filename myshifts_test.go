@@ -0,0 +1,29 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroupByMonth(t *testing.T) {
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2025, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	shifts := []Shift{
+		{ID: 1, Begin: at(1, 30, 8), End: at(1, 30, 12), Takes: []Take{{Name: "x", Approved: true}}},
+		{ID: 2, Begin: at(1, 31, 22), End: at(2, 1, 6), Takes: []Take{{Name: "x", Approved: true, ActualBegin: at(1, 31, 22), ActualEnd: at(2, 1, 7)}}},
+		{ID: 3, Begin: at(2, 3, 8), End: at(2, 3, 12), Takes: []Take{{Name: "x"}}},
+		{ID: 4, Begin: at(2, 4, 8), End: at(2, 4, 12), Takes: []Take{{Name: "x", Approved: true, Attendance: NoShow}}},
+		{ID: 5, Begin: at(2, 5, 8), End: at(2, 5, 12), Break: 30 * time.Minute, Takes: []Take{{Name: "x", Approved: true}}},
+	}
+	got := GroupByMonth(shifts, time.UTC)
+	if len(got) != 2 {
+		t.Fatalf("got %d months, want 2", len(got))
+	}
+	if !got[0].Month.Equal(at(1, 1, 0)) || len(got[0].Shifts) != 2 || got[0].Hours != 13 {
+		t.Fatalf("got %v with %d shifts and %v hours", got[0].Month, len(got[0].Shifts), got[0].Hours)
+	}
+	if !got[1].Month.Equal(at(2, 1, 0)) || len(got[1].Shifts) != 3 || got[1].Hours != 3.5 {
+		t.Fatalf("got %v with %d shifts and %v hours", got[1].Month, len(got[1].Shifts), got[1].Hours)
+	}
+}
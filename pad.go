@@ -3,6 +3,7 @@ package shiftpad
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"math"
 	"time"
 )
 
@@ -17,6 +18,15 @@ type Pad struct {
 	Location    *time.Location // must not be nil
 	Name        string
 	ShiftNames  []string
+
+	BreakRules      []BreakRule
+	Currency        string   // appended to money amounts, like "EUR" or "€"
+	Holidays        []string // yyyy-mm-dd, for Surcharge.Holidays
+	Limits          Limits
+	Rates           map[string]float64 // hourly rates per shift name
+	RejectOverlaps  bool               // reject takes which overlap with another shift of the same taker, else just warn
+	Surcharges      []Surcharge
+	WaitlistApprove bool // promote waiters with take permission to approved takes, else to applications, unless RejectOverlaps or Limits are set
 }
 
 func NewPad() *Pad {
@@ -27,6 +37,24 @@ func NewPad() *Pad {
 	}
 }
 
+// Rate returns the hourly rate of the shift, which is either set in the shift or in the pad.
+func (pad Pad) Rate(shift Shift) float64 {
+	if shift.Rate > 0 {
+		return shift.Rate
+	}
+	return pad.Rates[shift.Name]
+}
+
+// Amount returns the money amount of the shift, rounded to cents.
+func (pad Pad) Amount(shift Shift) float64 {
+	return Amount(shift.Hours(), pad.Rate(shift))
+}
+
+// Amount returns hours times rate, rounded to cents.
+func Amount(hours, rate float64) float64 {
+	return math.Round(hours*rate*100) / 100
+}
+
 func NewShareID() string {
 	return randStr(20)
 }
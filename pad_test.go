@@ -0,0 +1,24 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAmount(t *testing.T) {
+	begin := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+	pad := Pad{Rates: map[string]float64{"bar": 12.5}}
+	tests := []struct {
+		shift Shift
+		want  float64
+	}{
+		{Shift{Name: "bar", Begin: begin, End: begin.Add(90 * time.Minute)}, 18.75},
+		{Shift{Name: "bar", Rate: 10, Begin: begin, End: begin.Add(20 * time.Minute)}, 3.33}, // override, rounded to cents
+		{Shift{Name: "door", Begin: begin, End: begin.Add(time.Hour)}, 0},
+	}
+	for i, test := range tests {
+		if got := pad.Amount(test.shift); got != test.want {
+			t.Fatalf("test %d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
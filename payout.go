@@ -0,0 +1,134 @@
+package shiftpad
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var ErrPaidOut = errors.New("this shift has just been paid out by someone else")
+var ErrPayoutUndone = errors.New("this payout has already been undone")
+
+// Payout is a batch of takes of one taker which have been marked as paid out together.
+type Payout struct {
+	ID         int
+	Created    time.Time
+	Payer      string // note of the share link which was used
+	Taker      string
+	Hours      float64
+	Amount     float64
+	Takes      []PayoutTake
+	UndoneTime time.Time // zero if not undone
+}
+
+// PayoutTake is a take in a payout. The shift name is stored with the payout because the take might be deleted later.
+type PayoutTake struct {
+	TakeID    int
+	ShiftName string
+}
+
+// ShiftNames returns the distinct shift names of the takes, sorted.
+func (payout Payout) ShiftNames() []string {
+	var names []string
+	for _, take := range payout.Takes {
+		names = append(names, take.ShiftName)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func (payout Payout) Undone() bool {
+	return !payout.UndoneTime.IsZero()
+}
+
+// Add adds a take of the shift to the payout. Actual times of the take are used if they have been recorded.
+func (payout *Payout) Add(pad *Pad, shift Shift, take Take) {
+	worked := shift.Worked(take)
+	payout.Hours += worked.Hours()
+	payout.Amount += pad.Amount(worked)
+	payout.Takes = append(payout.Takes, PayoutTake{
+		TakeID:    take.ID,
+		ShiftName: shift.Name,
+	})
+}
+
+// PayoutTotal sums the worked hours, amounts and surcharges of takes.
+type PayoutTotal struct {
+	Hours      float64
+	Amount     float64
+	Surcharges []SurchargeHours
+}
+
+// Add adds the worked time of a take to the total. If someone has multiple takes of a shift, the hours are also added multiple times.
+func (total *PayoutTotal) Add(pad *Pad, shift Shift, take Take) {
+	worked := shift.Worked(take)
+	total.Hours += worked.Hours()
+	total.Amount += pad.Amount(worked)
+	total.Surcharges = AddSurchargeHours(total.Surcharges, pad.SurchargeHours(worked))
+}
+
+// PayoutSum sums the approved takes of a taker in shifts with the same name. Only paid shifts and paid out takes are considered.
+type PayoutSum struct {
+	Taker             string
+	ShiftName         string
+	Rate              float64 // hourly rate of the shifts if set there, else zero
+	PaidOutTakes      int
+	PaidOutHours      float64
+	PaidOutAmount     float64
+	OutstandingTakes  int
+	OutstandingHours  float64
+	OutstandingAmount float64
+}
+
+func (sum *PayoutSum) add(other PayoutSum) {
+	sum.PaidOutTakes += other.PaidOutTakes
+	sum.PaidOutHours += other.PaidOutHours
+	sum.PaidOutAmount += other.PaidOutAmount
+	sum.OutstandingTakes += other.OutstandingTakes
+	sum.OutstandingHours += other.OutstandingHours
+	sum.OutstandingAmount += other.OutstandingAmount
+}
+
+// TakerPayouts contains the payout sums of a taker per shift name.
+type TakerPayouts struct {
+	Taker string
+	Sums  []PayoutSum
+	Total PayoutSum
+}
+
+type PayoutReport struct {
+	Takers []TakerPayouts
+	Total  PayoutSum
+}
+
+// NewPayoutReport computes amounts and totals from sums which are sorted by taker and shift name.
+// Sums of the same taker and shift name but with different rates are merged.
+func NewPayoutReport(pad *Pad, sums []PayoutSum) PayoutReport {
+	var report PayoutReport
+	for _, sum := range sums {
+		rate := pad.Rate(Shift{Name: sum.ShiftName, Rate: sum.Rate})
+		sum.Rate = rate
+		sum.PaidOutAmount = Amount(sum.PaidOutHours, rate)
+		sum.OutstandingAmount = Amount(sum.OutstandingHours, rate)
+
+		if len(report.Takers) == 0 || report.Takers[len(report.Takers)-1].Taker != sum.Taker {
+			report.Takers = append(report.Takers, TakerPayouts{
+				Taker: sum.Taker,
+				Total: PayoutSum{Taker: sum.Taker},
+			})
+		}
+		taker := &report.Takers[len(report.Takers)-1]
+		if len(taker.Sums) > 0 && taker.Sums[len(taker.Sums)-1].ShiftName == sum.ShiftName {
+			last := &taker.Sums[len(taker.Sums)-1]
+			last.add(sum)
+			if last.Rate != rate {
+				last.Rate = 0 // mixed rates
+			}
+		} else {
+			taker.Sums = append(taker.Sums, sum)
+		}
+		taker.Total.add(sum)
+		report.Total.add(sum)
+	}
+	return report
+}
@@ -0,0 +1,49 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPayoutReport(t *testing.T) {
+	pad := &Pad{Rates: map[string]float64{"bar": 10}}
+	report := NewPayoutReport(pad, []PayoutSum{
+		{Taker: "alice", ShiftName: "bar", PaidOutTakes: 1, PaidOutHours: 2},
+		{Taker: "alice", ShiftName: "bar", Rate: 20, OutstandingTakes: 1, OutstandingHours: 1}, // overridden rate
+		{Taker: "alice", ShiftName: "door", PaidOutTakes: 1, PaidOutHours: 3},
+		{Taker: "bob", ShiftName: "bar", OutstandingTakes: 2, OutstandingHours: 4},
+	})
+
+	if len(report.Takers) != 2 || len(report.Takers[0].Sums) != 2 || len(report.Takers[1].Sums) != 1 {
+		t.Fatalf("got %+v", report)
+	}
+	if bar := report.Takers[0].Sums[0]; bar.PaidOutAmount != 20 || bar.OutstandingAmount != 20 || bar.Rate != 0 {
+		t.Fatalf("merged sum: got %+v", bar)
+	}
+	if total := report.Takers[0].Total; total.PaidOutTakes != 2 || total.PaidOutHours != 5 || total.PaidOutAmount != 20 {
+		t.Fatalf("taker total: got %+v", total)
+	}
+	if total := report.Total; total.OutstandingTakes != 3 || total.OutstandingHours != 5 || total.OutstandingAmount != 60 {
+		t.Fatalf("total: got %+v", total)
+	}
+}
+
+func TestPayoutTotal(t *testing.T) {
+	pad := &Pad{
+		Location:   time.UTC,
+		Rates:      map[string]float64{"bar": 10},
+		Surcharges: []Surcharge{{Name: "night", Percent: 50, From: 22 * 60, To: 6 * 60}},
+	}
+	shift := Shift{Name: "bar", Begin: time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+
+	var total PayoutTotal
+	total.Add(pad, shift, Take{Name: "alice"})
+	total.Add(pad, shift, Take{Name: "bob", ActualBegin: time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC), ActualEnd: time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)})
+
+	if total.Hours != 5 || total.Amount != 50 {
+		t.Fatalf("got %+v", total)
+	}
+	if len(total.Surcharges) != 1 || total.Surcharges[0].Hours != 3 || total.Surcharges[0].Amount != 15 {
+		t.Fatalf("surcharges: got %+v", total.Surcharges)
+	}
+}
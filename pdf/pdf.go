@@ -0,0 +1,97 @@
+// Package pdf writes simple PDF documents with text and lines, using the standard Helvetica fonts. It has no external dependencies, so documents can be generated on the server.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Document is a PDF document. Coordinates are in points, measured from the top left corner of the page.
+type Document struct {
+	pages []*bytes.Buffer // content streams
+}
+
+// AddPage adds a new page. Subsequent drawing goes to it.
+func (doc *Document) AddPage() {
+	doc.pages = append(doc.pages, &bytes.Buffer{})
+}
+
+func (doc *Document) page() *bytes.Buffer {
+	if len(doc.pages) == 0 {
+		doc.AddPage()
+	}
+	return doc.pages[len(doc.pages)-1]
+}
+
+// Text draws s with its baseline at y. Characters which are not in the Windows-1252 charset are replaced.
+func (doc *Document) Text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(doc.page(), "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PageHeight-y, escape(s))
+}
+
+// Line draws a thin line from (x1, y1) to (x2, y2).
+func (doc *Document) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(doc.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// WriteTo writes the document in PDF format to w.
+func (doc *Document) WriteTo(w io.Writer) (int64, error) {
+	doc.page() // a document has at least one page
+
+	var buf bytes.Buffer
+	var offsets []int // byte offset of each object, object numbers start at 1
+	object := func(format string, a ...any) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", len(offsets))
+		fmt.Fprintf(&buf, format, a...)
+		buf.WriteString("\nendobj\n")
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// objects 1 to 4, pages start at object 5 and consist of a page object and a content stream object
+	var kids []string
+	for i := range doc.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(doc.pages))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range doc.pages {
+		object("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", PageWidth, PageHeight, 6+2*i)
+		object("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.Bytes())
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// escape encodes s to Windows-1252 and escapes it for a PDF string literal.
+func escape(s string) string {
+	encoded, err := encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder()).String(s) // encoders are not thread-safe
+	if err != nil {
+		encoded = "?"
+	}
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", " ", "\n", " ").Replace(encoded)
+}
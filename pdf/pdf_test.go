@@ -0,0 +1,52 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	var doc Document
+	doc.Text(50, 50, 12, true, "Quittung (Auszahlung) für Jürgen – 12,50 €")
+	doc.Line(50, 60, 200, 60)
+	doc.AddPage()
+	doc.Text(50, 50, 10, false, `back\slash`)
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("invalid header or trailer")
+	}
+	if !bytes.Contains(out, []byte("/Count 2")) {
+		t.Fatalf("want two pages")
+	}
+	if !bytes.Contains(out, []byte("(Quittung \\(Auszahlung\\) f\xfcr J\xfcrgen \x96 12,50 \x80)")) {
+		t.Fatalf("text is not encoded and escaped")
+	}
+	if !bytes.Contains(out, []byte(`(back\\slash)`)) {
+		t.Fatalf("backslash is not escaped")
+	}
+
+	// check that the xref table points to the objects
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if startxref == nil {
+		t.Fatalf("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point to xref table")
+	}
+	for i, match := range regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1) {
+		offset, _ := strconv.Atoi(string(match[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Fatalf("xref entry %d does not point to %q", i+1, want)
+		}
+	}
+}
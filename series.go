@@ -0,0 +1,72 @@
+package shiftpad
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// MaxOccurrences limits the number of shifts which are created from a recurrence rule.
+const MaxOccurrences = 256
+
+// Scope of an edit or delete operation on a shift which belongs to a series.
+const (
+	ScopeThis      = "this"
+	ScopeFollowing = "following"
+	ScopeSeries    = "series"
+)
+
+// Occurrences returns the begin times of a series which starts at dtstart and repeats according to an RFC 5545 RRULE like "FREQ=WEEKLY;COUNT=4".
+// The first occurrence is dtstart. Occurrences after limit are omitted.
+// Times are calculated in the location of dtstart, so the wall clock time stays the same across DST changes.
+func Occurrences(rule string, dtstart, limit time.Time) ([]time.Time, error) {
+	rule = strings.TrimSpace(rule)
+	rule = strings.TrimPrefix(rule, "RRULE:")
+	if rule == "" {
+		return nil, errors.New("empty recurrence rule")
+	}
+
+	opt, err := rrule.StrToROptionInLocation(rule, dtstart.Location())
+	if err != nil {
+		return nil, err
+	}
+	opt.Dtstart = dtstart
+	r, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences = []time.Time{dtstart}
+	next := r.Iterator()
+	for len(occurrences) < MaxOccurrences {
+		t, ok := next()
+		if !ok || t.After(limit) {
+			break
+		}
+		if t.Equal(dtstart) {
+			continue // dtstart is returned by the iterator only if it matches the rule
+		}
+		occurrences = append(occurrences, t)
+	}
+	return occurrences, nil
+}
+
+// Reschedule returns the new begin and end of a series occurrence which begins at t, after another occurrence of the series has been moved from oldBegin to newBegin and newEnd.
+// The difference in calendar days and the new wall clock times are applied, so the result is correct across DST changes.
+func Reschedule(t, oldBegin, newBegin, newEnd time.Time) (time.Time, time.Time) {
+	loc := t.Location()
+	days := daysBetween(oldBegin, newBegin)
+	begin := time.Date(t.Year(), t.Month(), t.Day()+days, newBegin.Hour(), newBegin.Minute(), 0, 0, loc)
+	endDays := daysBetween(newBegin, newEnd)
+	end := time.Date(begin.Year(), begin.Month(), begin.Day()+endDays, newEnd.Hour(), newEnd.Minute(), 0, 0, loc)
+	return begin, end
+}
+
+// daysBetween returns the number of calendar days from the date of a to the date of b.
+func daysBetween(a, b time.Time) int {
+	ad := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	bd := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(bd.Sub(ad).Hours() / 24)
+}
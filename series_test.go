@@ -0,0 +1,68 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOccurrences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	dtstart := time.Date(2024, time.March, 20, 10, 0, 0, 0, berlin) // Wed, DST begins on Sun 2024-03-31
+	limit := dtstart.AddDate(1, 0, 0)
+
+	got, err := Occurrences("RRULE:FREQ=WEEKLY;COUNT=3", dtstart, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Time{
+		time.Date(2024, time.March, 20, 10, 0, 0, 0, berlin),
+		time.Date(2024, time.March, 27, 10, 0, 0, 0, berlin),
+		time.Date(2024, time.April, 3, 10, 0, 0, 0, berlin),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d occurrences, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("occurrence %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// limit
+	got, err = Occurrences("FREQ=DAILY", dtstart, dtstart.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d occurrences, want 3", len(got))
+	}
+
+	if _, err := Occurrences("FREQ=NEVER", dtstart, limit); err == nil {
+		t.Fatal("invalid rule: got no error")
+	}
+}
+
+func TestReschedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// an occurrence has been moved from Wed 10:00 to Thu 09:00–17:00
+	oldBegin := time.Date(2024, time.March, 20, 10, 0, 0, 0, berlin)
+	newBegin := time.Date(2024, time.March, 21, 9, 0, 0, 0, berlin)
+	newEnd := time.Date(2024, time.March, 21, 17, 0, 0, 0, berlin)
+
+	// next occurrence is after the DST change
+	begin, end := Reschedule(time.Date(2024, time.April, 3, 10, 0, 0, 0, berlin), oldBegin, newBegin, newEnd)
+	if want := time.Date(2024, time.April, 4, 9, 0, 0, 0, berlin); !begin.Equal(want) {
+		t.Fatalf("begin: got %v, want %v", begin, want)
+	}
+	if want := time.Date(2024, time.April, 4, 17, 0, 0, 0, berlin); !end.Equal(want) {
+		t.Fatalf("end: got %v, want %v", end, want)
+	}
+}
@@ -1,6 +1,7 @@
 package shiftpad
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"time"
@@ -11,28 +12,59 @@ import (
 // MaxFuture specifies how far in the future shifts can be created and edited, and the expiry time of pads.
 const MaxFuture = 180 * 24 * time.Hour
 
+// ErrFullyTaken is returned by the storage layer if a take or approval would exceed Shift.Quantity.
+var ErrFullyTaken = errors.New("this shift has just been fully taken by someone else")
+
+// ErrOfferGone is returned by the storage layer if a handover offer has been accepted or withdrawn in the meantime.
+var ErrOfferGone = errors.New("this shift has just been handed over to someone else or the offer has been withdrawn")
+
 type Shift struct {
 	ID       int
-	Modified time.Time // used in ical export
+	Modified time.Time // used in ical export and as CalDAV etag, updated on every change of the shift or its takes and waiters
 	Name     string    // matched against Pad.ShiftNames
 	Note     string
 	Paid     bool
 	EventUID string
 	Quantity int
-	Begin    time.Time // required
-	End      time.Time // required
+	Begin    time.Time     // required
+	End      time.Time     // required
+	SeriesID int           // id of the first shift of a recurring series, zero if the shift does not recur
+	Rate     float64       // hourly rate, overrides Pad.Rates if not zero
+	Break    time.Duration // unpaid, see Pad.MinBreak
 	Takes    []Take
+	Waitlist []Waiter // in order of joining
 }
 
 // AfterDeadline returns true if the shift begins after the next deadline.
+// The deadline is either a cron expression or a duration like "48h", which is interpreted as the time span before the shift begins.
 func (shift Shift) AfterDeadline(deadline string, now time.Time) bool {
 	if deadline == "" {
 		return true // no deadline
 	}
+	if d, err := time.ParseDuration(deadline); err == nil {
+		return shift.Begin.After(now.Add(d))
+	}
 	nextDeadline := cronexpr.MustParse(deadline).Next(now)
 	return shift.Begin.After(nextDeadline)
 }
 
+// CopyTo returns a copy of the shift without takes, moved by the number of calendar days between from and to.
+// The wall clock times are kept, also across DST changes. The copy does not belong to a series.
+func (shift Shift) CopyTo(from, to time.Time) Shift {
+	days := daysBetween(from, to)
+	return Shift{
+		Name:     shift.Name,
+		Note:     shift.Note,
+		Paid:     shift.Paid,
+		EventUID: shift.EventUID,
+		Quantity: shift.Quantity,
+		Rate:     shift.Rate,
+		Break:    shift.Break,
+		Begin:    shift.Begin.AddDate(0, 0, days),
+		End:      shift.End.AddDate(0, 0, days),
+	}
+}
+
 func (shift Shift) FullyTaken() bool {
 	var approved = 0
 	for _, take := range shift.Takes {
@@ -52,8 +84,62 @@ func (shift Shift) HasPayouts() bool {
 	return false
 }
 
+// HasTaker returns true if name has a take which is not rejected.
+func (shift Shift) HasTaker(name string) bool {
+	for _, take := range shift.Takes {
+		if take.Name == name && !take.Rejected {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTakerOrWaiter returns true if name has a take which is not rejected, or is on the waitlist.
+func (shift Shift) HasTakerOrWaiter(name string) bool {
+	if shift.HasTaker(name) {
+		return true
+	}
+	for _, waiter := range shift.Waitlist {
+		if waiter.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Hours returns the net hours of the shift, without the unpaid break.
 func (shift Shift) Hours() float64 {
-	return shift.End.Sub(shift.Begin).Hours()
+	return max(shift.End.Sub(shift.Begin)-shift.Break, 0).Hours()
+}
+
+// Worked returns a copy of the shift with the actual begin and end of the take, if they have been recorded.
+// Payouts use the worked shift.
+func (shift Shift) Worked(take Take) Shift {
+	if take.Recorded() {
+		shift.Begin = take.ActualBegin.In(shift.Begin.Location())
+		shift.End = take.ActualEnd.In(shift.End.Location())
+	}
+	return shift
+}
+
+// ResetRecorded resets the recorded times of the takes which have not been paid out, if begin or end of the shift differ from original.
+// Recorded times would refer to the original date then.
+func (shift *Shift) ResetRecorded(original Shift) {
+	if shift.Begin.Equal(original.Begin) && shift.End.Equal(original.End) {
+		return
+	}
+	shift.Takes = slices.Clone(shift.Takes) // might be shared with original
+	for i := range shift.Takes {
+		if !shift.Takes[i].PaidOut {
+			shift.Takes[i].ActualBegin = time.Time{}
+			shift.Takes[i].ActualEnd = time.Time{}
+		}
+	}
+}
+
+// WorkedDiff returns the worked hours of the take minus the planned hours of the shift.
+func (shift Shift) WorkedDiff(take Take) float64 {
+	return shift.Worked(take).Hours() - shift.Hours()
 }
 
 func (shift Shift) String() string {
@@ -65,12 +151,16 @@ func (shift Shift) String() string {
 }
 
 // TakeViews returns shift.Takes with auth.ViewTakerName and auth.ViewTakerContact applied.
-// Anonymous takes are summarized to "n × anonymous".
+// Anonymous takes are summarized to "n × anonymous". Rejected takes are only returned to their taker names.
 func (shift Shift) TakeViews(auth Auth) []Take {
 	var takers []Take
 	var anonymousApplied int
 	var anonymousApproved int
 	for _, take := range shift.Takes {
+		if take.Rejected && !slices.Contains(auth.TakerName, take.Name) {
+			continue
+		}
+
 		// copy authorized data to local variables
 		var takerName string
 		var takerContact string
@@ -81,7 +171,7 @@ func (shift Shift) TakeViews(auth Auth) []Take {
 			takerContact = take.Contact
 		}
 
-		if takerName == "" && takerContact == "" {
+		if takerName == "" && takerContact == "" && !take.Offered { // offered takes are listed individually, so they can be accepted
 			if take.Approved {
 				anonymousApproved++
 			} else {
@@ -94,11 +184,17 @@ func (shift Shift) TakeViews(auth Auth) []Take {
 			takerName = "anonymous"
 		}
 		takers = append(takers, Take{
-			ID:       take.ID,
-			Name:     takerName,
-			Contact:  takerContact,
-			Approved: take.Approved,
-			PaidOut:  take.PaidOut,
+			ID:           take.ID,
+			Name:         takerName,
+			Contact:      takerContact,
+			Approved:     take.Approved,
+			PaidOut:      take.PaidOut,
+			Rejected:     take.Rejected,
+			RejectReason: take.RejectReason,
+			Offered:      take.Offered,
+			ActualBegin:  take.ActualBegin.In(shift.Begin.Location()),
+			ActualEnd:    take.ActualEnd.In(shift.End.Location()),
+			Attendance:   take.Attendance,
 		})
 	}
 	if anonymousApproved > 0 {
@@ -117,7 +213,12 @@ func (shift Shift) TakeViews(auth Auth) []Take {
 }
 
 func (shift Shift) Untaken() []struct{} {
-	var untaken = shift.Quantity - len(shift.Takes)
+	var untaken = shift.Quantity
+	for _, take := range shift.Takes {
+		if !take.Rejected {
+			untaken--
+		}
+	}
 	if untaken < 0 {
 		untaken = 0
 	}
@@ -128,12 +229,52 @@ func (shift Shift) Over() bool {
 	return time.Now().After(shift.End)
 }
 
+// Waiter is a person on the waitlist of a fully taken shift. When a take is removed, the first waiter is promoted to a take.
+type Waiter struct {
+	ID      int
+	Name    string
+	Contact string
+	Take    bool // joined with take permission, so the waiter can be promoted to an approved take (see Pad.WaitlistApprove)
+}
+
+// WaitlistViews returns shift.Waitlist with auth.ViewTakerName and auth.ViewTakerContact applied.
+// Unlike in TakeViews, hidden names are not summarized, so the position in the waitlist stays visible.
+func (shift Shift) WaitlistViews(auth Auth) []Waiter {
+	var waiters []Waiter
+	for _, waiter := range shift.Waitlist {
+		var view = Waiter{
+			Name: "anonymous",
+			Take: waiter.Take,
+		}
+		if auth.ViewTakerName || slices.Contains(auth.Edit, shift.Name) || slices.Contains(auth.TakerName, waiter.Name) {
+			view.ID = waiter.ID
+			view.Name = waiter.Name
+		}
+		if auth.ViewTakerContact || slices.Contains(auth.Edit, shift.Name) {
+			view.Contact = waiter.Contact
+		}
+		waiters = append(waiters, view)
+	}
+	return waiters
+}
+
 type Take struct {
-	ID       int
-	Name     string
-	Contact  string
-	Approved bool
-	PaidOut  bool // not PaymentDue (although the zero value would be a good default) because its meaning would change if shift.Paid is changed, and because keeping track of payments is important
+	ID           int
+	Name         string
+	Contact      string
+	Approved     bool
+	PaidOut      bool // not PaymentDue (although the zero value would be a good default) because its meaning would change if shift.Paid is changed, and because keeping track of payments is important
+	Rejected     bool // rejected applications are kept so the applicant can see them
+	RejectReason string
+	Offered      bool      // offered for handover by the taker, see Auth.CanAcceptOffer
+	ActualBegin  time.Time // zero if not recorded, see Auth.CanRecordTake
+	ActualEnd    time.Time
+	Attendance   Attendance
+}
+
+// Recorded returns true if the actual begin and end of the take have been recorded.
+func (take Take) Recorded() bool {
+	return !take.ActualBegin.IsZero() && !take.ActualEnd.IsZero()
 }
 
 func (take Take) String() string {
@@ -141,7 +282,10 @@ func (take Take) String() string {
 	if take.Contact != "" {
 		s = s + " (" + take.Contact + ")"
 	}
-	if !take.Approved {
+	switch {
+	case take.Rejected:
+		s = s + " (rejected)"
+	case !take.Approved:
 		s = s + " (applied)"
 	}
 	return s
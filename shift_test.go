@@ -33,3 +33,107 @@ func TestAfterDeadline(t *testing.T) {
 		}
 	}
 }
+
+func TestAfterDeadlineDuration(t *testing.T) {
+	shift := Shift{
+		Begin: time.Date(2024, time.November, 26, 10, 0, 0, 0, time.UTC),
+	}
+
+	const deadline = "48h"
+
+	tests := []struct {
+		now  time.Time
+		want bool
+	}{
+		{time.Date(2024, time.November, 24, 9, 59, 0, 0, time.UTC), true},
+		{time.Date(2024, time.November, 24, 10, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, time.November, 25, 10, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, test := range tests {
+		if got := shift.AfterDeadline(deadline, test.now); got != test.want {
+			t.Fatalf("got %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestCopyTo(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	shift := Shift{
+		ID:       1,
+		Name:     "a",
+		Quantity: 2,
+		Begin:    time.Date(2024, time.March, 25, 22, 0, 0, 0, berlin), // Mon, DST begins on Sun 2024-03-31
+		End:      time.Date(2024, time.March, 26, 6, 0, 0, 0, berlin),
+		SeriesID: 1,
+		Takes:    []Take{{Name: "x", Approved: true}},
+	}
+	from := time.Date(2024, time.March, 25, 0, 0, 0, 0, berlin)
+	to := time.Date(2024, time.April, 1, 0, 0, 0, 0, berlin)
+
+	got := shift.CopyTo(from, to)
+	if want := time.Date(2024, time.April, 1, 22, 0, 0, 0, berlin); !got.Begin.Equal(want) {
+		t.Fatalf("begin: got %v, want %v", got.Begin, want)
+	}
+	if want := time.Date(2024, time.April, 2, 6, 0, 0, 0, berlin); !got.End.Equal(want) {
+		t.Fatalf("end: got %v, want %v", got.End, want)
+	}
+	if got.ID != 0 || got.SeriesID != 0 || len(got.Takes) != 0 {
+		t.Fatalf("copy has id %d, series %d and %d takes", got.ID, got.SeriesID, len(got.Takes))
+	}
+}
+
+func TestWorked(t *testing.T) {
+	shift := Shift{
+		Begin: time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, time.March, 3, 16, 0, 0, 0, time.UTC),
+	}
+	if got := shift.Worked(Take{}); !got.Begin.Equal(shift.Begin) || !got.End.Equal(shift.End) {
+		t.Fatalf("got %v to %v, want planned times", got.Begin, got.End)
+	}
+	recorded := Take{
+		ActualBegin: time.Date(2025, time.March, 3, 8, 15, 0, 0, time.UTC),
+		ActualEnd:   time.Date(2025, time.March, 3, 17, 0, 0, 0, time.UTC),
+	}
+	if got := shift.Worked(recorded).Hours(); got != 8.75 {
+		t.Fatalf("got %v hours, want 8.75", got)
+	}
+	if got := shift.WorkedDiff(recorded); got != 0.75 {
+		t.Fatalf("got diff %v, want 0.75", got)
+	}
+}
+
+func TestResetRecorded(t *testing.T) {
+	begin := time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC)
+	actualBegin := begin.Add(15 * time.Minute)
+	actualEnd := begin.Add(9 * time.Hour)
+	original := Shift{
+		Begin: begin,
+		End:   begin.Add(8 * time.Hour),
+		Takes: []Take{
+			{Name: "alice", ActualBegin: actualBegin, ActualEnd: actualEnd},
+			{Name: "bob", ActualBegin: actualBegin, ActualEnd: actualEnd, PaidOut: true},
+		},
+	}
+
+	shift := original
+	shift.Note = "changed"
+	shift.ResetRecorded(original)
+	if !shift.Takes[0].Recorded() {
+		t.Fatalf("unmoved shift: recorded times have been reset")
+	}
+
+	shift.Begin = shift.Begin.AddDate(0, 0, 1)
+	shift.End = shift.End.AddDate(0, 0, 1)
+	shift.ResetRecorded(original)
+	if shift.Takes[0].Recorded() || !shift.Takes[1].Recorded() {
+		t.Fatalf("moved shift: got %+v, want recorded times of the paid out take only", shift.Takes)
+	}
+	if !original.Takes[0].Recorded() {
+		t.Fatalf("original has been changed")
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,33 +21,66 @@ import (
 
 var ErrUnauthorized = errors.New("unauthorized")
 
+// workedSeconds is an sql expression for the net duration of a take, using the actual times if they have been recorded, minus the break
+const workedSeconds = `max(0, case when taker.actual_end > 0 then taker.actual_end - taker.actual_begin else shift.end - shift.begin end - shift.break_minutes * 60)`
+
 type DB struct {
 	SQLDB                *sql.DB
+	acceptOffer          *sql.Stmt
+	addCalDAVObject      *sql.Stmt
 	addPad               *sql.Stmt
+	addPayout            *sql.Stmt
+	addPayoutTake        *sql.Stmt
 	addShare             *sql.Stmt
 	addShift             *sql.Stmt
 	addTaker             *sql.Stmt
 	addTakerWithID       *sql.Stmt
+	addWaiter            *sql.Stmt
 	approveTake          *sql.Stmt
 	deletePad            *sql.Stmt
 	deletePads           *sql.Stmt
+	deleteShare          *sql.Stmt
 	deleteShift          *sql.Stmt
 	deleteShifts         *sql.Stmt
+	deleteTaker          *sql.Stmt
 	deleteTakers         *sql.Stmt
+	deleteWaiter         *sql.Stmt
+	deleteWaiterByName   *sql.Stmt
+	detachPayoutTake     *sql.Stmt
+	detachPayoutTakes    *sql.Stmt
+	getCalDAVObject      *sql.Stmt
+	getCalDAVObjects     *sql.Stmt
+	getFreeCapacity      *sql.Stmt
 	getPad               *sql.Stmt
+	getPayout            *sql.Stmt
+	getPayoutSums        *sql.Stmt
+	getPayoutTakes       *sql.Stmt
+	getPayouts           *sql.Stmt
 	getShare             *sql.Stmt
 	getShares            *sql.Stmt
 	getShift             *sql.Stmt
 	getShifts            *sql.Stmt
 	getShiftsByEvent     *sql.Stmt
+	getShiftsBySeries    *sql.Stmt
+	getTakerIDs          *sql.Stmt
 	getTakerNames        *sql.Stmt
 	getTakersByShift     *sql.Stmt
 	getTakesByName       *sql.Stmt
+	getWaitersByShift    *sql.Stmt
+	offerTake            *sql.Stmt
+	recordTake           *sql.Stmt
+	rejectTake           *sql.Stmt
+	setAttendance        *sql.Stmt
 	setPaidOut           *sql.Stmt
+	takeShift            *sql.Stmt
+	undoPayout           *sql.Stmt
+	undoPayoutTakes      *sql.Stmt
 	updatePad            *sql.Stmt
 	updatePadLastUpdated *sql.Stmt
+	updateShare          *sql.Stmt
 	updateShift          *sql.Stmt
 	updateShiftModified  *sql.Stmt
+	updateShiftSeries    *sql.Stmt
 }
 
 func OpenDB(dbpath string) (*DB, error) {
@@ -65,7 +101,18 @@ func OpenDB(dbpath string) (*DB, error) {
 			last_updated text not null,
 			location     text not null,
 			name         text not null,
-			shift_names  text not null
+			shift_names  text not null,
+			waitlist_approve boolean not null default false,
+			reject_overlaps  boolean not null default false,
+			currency         text not null default '',
+			rates            text not null default '', -- hourly rates per shift name, url-encoded
+			surcharges       text not null default '', -- one per line
+			holidays         text not null default '', -- one per line
+			break_rules      text not null default '', -- one per line
+			min_rest         real not null default 0,
+			max_hours_day    real not null default 0,
+			max_hours_week   real not null default 0,
+			max_hours_month  real not null default 0
 		);
 		create table if not exists share (
 			secret text primary key,
@@ -83,16 +130,57 @@ func OpenDB(dbpath string) (*DB, error) {
 			quantity      integer not null,
 			begin         integer not null,
 			end           integer not null,
+			series        integer not null default 0, -- id of the first shift of the series, or zero
+			rate          real    not null default 0, -- hourly rate, overrides the rate of the shift name if not zero
+			break_minutes integer not null default 0, -- unpaid break
 			foreign key (pad) references pad(id) on update cascade on delete cascade
 		);
 		create table if not exists taker (
-			id       integer primary key,
-			pad      text    not null, -- for easy select
-			shift    integer not null,
-			name     text    not null,
-			contact  text    not null,
-			approved boolean not null,
-			paid_out boolean not null,
+			id            integer primary key,
+			pad           text    not null, -- for easy select
+			shift         integer not null,
+			name          text    not null,
+			contact       text    not null,
+			approved      boolean not null,
+			paid_out      boolean not null,
+			rejected      boolean not null default false,
+			reject_reason text    not null default '',
+			offered       boolean not null default false, -- offered for handover by the taker
+			actual_begin  integer not null default 0, -- zero if not recorded
+			actual_end    integer not null default 0,
+			attendance    integer not null default 0, -- see shiftpad.Attendance
+			foreign key (shift) references shift(id) on update cascade on delete cascade
+		);
+		create table if not exists payout (
+			id      integer primary key,
+			pad     text    not null,
+			created integer not null,
+			payer   text    not null, -- note of the share link
+			taker   text    not null,
+			hours   real    not null,
+			amount  real    not null default 0,
+			undone  integer not null default 0, -- zero if not undone
+			foreign key (pad) references pad(id) on update cascade on delete cascade
+		);
+		create table if not exists payout_take (
+			payout     integer not null,
+			take       integer not null, -- no foreign key, the take might be deleted, then zero because taker ids are reused
+			shift_name text    not null,
+			foreign key (payout) references payout(id) on update cascade on delete cascade
+		);
+		create table if not exists waiter (
+			id      integer primary key,
+			shift   integer not null,
+			name    text    not null,
+			contact text    not null,
+			take    boolean not null, -- joined with take permission
+			foreign key (shift) references shift(id) on update cascade on delete cascade
+		);
+		create table if not exists caldav_object (
+			pad   text    not null,
+			name  text    not null, -- resource name which a CalDAV client has chosen when creating the shift
+			shift integer not null,
+			primary key (pad, name),
 			foreign key (shift) references shift(id) on update cascade on delete cascade
 		);
 
@@ -101,10 +189,88 @@ func OpenDB(dbpath string) (*DB, error) {
 		create index if not exists pad_begin_index    on shift(pad, begin);
 		create index if not exists pad_end_index      on shift(pad, end);
 		create index if not exists pad_event_index    on shift(pad, event);
+		create index if not exists payout_pad_index   on payout(pad);
+		create index if not exists payout_take_index  on payout_take(payout);
 	`); err != nil {
 		return nil, err
 	}
 
+	// migrations
+	if err := addColumn(sqlDB, "shift", "series", "integer not null default 0"); err != nil {
+		return nil, err
+	}
+	if _, err := sqlDB.Exec(`create index if not exists pad_series_index on shift(pad, series)`); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "taker", "rejected", "boolean not null default false"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "taker", "reject_reason", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "taker", "offered", "boolean not null default false"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "pad", "waitlist_approve", "boolean not null default false"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "pad", "reject_overlaps", "boolean not null default false"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "pad", "currency", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "pad", "rates", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "pad", "surcharges", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "pad", "holidays", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "pad", "break_rules", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "shift", "rate", "real not null default 0"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "shift", "break_minutes", "integer not null default 0"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "payout", "amount", "real not null default 0"); err != nil {
+		return nil, err
+	}
+	for _, column := range []string{"actual_begin", "actual_end", "attendance"} {
+		if err := addColumn(sqlDB, "taker", column, "integer not null default 0"); err != nil {
+			return nil, err
+		}
+	}
+	for _, column := range []string{"min_rest", "max_hours_day", "max_hours_week", "max_hours_month"} {
+		if err := addColumn(sqlDB, "pad", column, "real not null default 0"); err != nil {
+			return nil, err
+		}
+	}
+
+	db.acceptOffer, err = sqlDB.Prepare(`
+		update taker
+		set
+			name = ?,
+			contact = ?,
+			offered = false
+		where id = ?
+			and shift = ?
+			and offered = true
+			and paid_out = false`)
+	if err != nil {
+		return nil, err
+	}
+	db.addCalDAVObject, err = sqlDB.Prepare(`
+		insert into caldav_object (pad, name, shift)
+		values (?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
 	db.addPad, err = sqlDB.Prepare(`
 		insert into pad (
 			id,
@@ -113,8 +279,40 @@ func OpenDB(dbpath string) (*DB, error) {
 			last_updated,
 			location,
 			name,
-			shift_names
-		) values (?, ?, ?, ?, ?, ?, ?)`)
+			shift_names,
+			waitlist_approve,
+			reject_overlaps,
+			min_rest,
+			max_hours_day,
+			max_hours_week,
+			max_hours_month,
+			currency,
+			rates,
+			surcharges,
+			holidays,
+			break_rules
+		) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	db.addPayout, err = sqlDB.Prepare(`
+		insert into payout (
+			pad,
+			created,
+			payer,
+			taker,
+			hours,
+			amount
+		) values (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	db.addPayoutTake, err = sqlDB.Prepare(`
+		insert into payout_take (
+			payout,
+			take,
+			shift_name
+		) values (?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -137,8 +335,11 @@ func OpenDB(dbpath string) (*DB, error) {
 			event,
 			quantity,
 			begin,
-			end
-		) values (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+			end,
+			series,
+			rate,
+			break_minutes
+		) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -149,8 +350,11 @@ func OpenDB(dbpath string) (*DB, error) {
 			name,
 			contact,
 			approved,
-			paid_out
-		) values (?, ?, ?, ?, ?, ?)`)
+			paid_out,
+			rejected,
+			reject_reason,
+			offered
+		) values (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -162,8 +366,24 @@ func OpenDB(dbpath string) (*DB, error) {
 			name,
 			contact,
 			approved,
-			paid_out
-		) values (?, ?, ?, ?, ?, ?, ?)`)
+			paid_out,
+			rejected,
+			reject_reason,
+			offered,
+			actual_begin,
+			actual_end,
+			attendance
+		) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	db.addWaiter, err = sqlDB.Prepare(`
+		insert into waiter (
+			shift,
+			name,
+			contact,
+			take
+		) values (?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +392,7 @@ func OpenDB(dbpath string) (*DB, error) {
 		set approved = true
 		where id = ?
 			and shift = ?
+			and (select count(*) from taker where shift = ? and approved = true) < (select quantity from shift where id = ?)
 	`)
 	if err != nil {
 		return nil, err
@@ -188,6 +409,13 @@ func OpenDB(dbpath string) (*DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	db.deleteShare, err = sqlDB.Prepare(`
+		delete from share
+		where secret = ?
+			and pad = ?`)
+	if err != nil {
+		return nil, err
+	}
 	db.deleteShift, err = sqlDB.Prepare(`
 		delete from shift
 		where id = ?`)
@@ -200,12 +428,88 @@ func OpenDB(dbpath string) (*DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	db.deleteTaker, err = sqlDB.Prepare(`
+		delete from taker
+		where id = ?
+			and shift = ?`)
+	if err != nil {
+		return nil, err
+	}
 	db.deleteTakers, err = sqlDB.Prepare(`
 		delete from taker
 		where shift = ?`)
 	if err != nil {
 		return nil, err
 	}
+	db.deleteWaiter, err = sqlDB.Prepare(`
+		delete from waiter
+		where id = ?
+			and shift = ?`)
+	if err != nil {
+		return nil, err
+	}
+	db.deleteWaiterByName, err = sqlDB.Prepare(`
+		delete from waiter
+		where shift = ?
+			and name = ?`)
+	if err != nil {
+		return nil, err
+	}
+	db.detachPayoutTake, err = sqlDB.Prepare(`
+		update payout_take
+		set take = 0
+		where take = ?`)
+	if err != nil {
+		return nil, err
+	}
+	db.detachPayoutTakes, err = sqlDB.Prepare(`
+		update payout_take
+		set take = 0
+		where take in (
+			select id
+			from taker
+			where shift = ?
+		)`)
+	if err != nil {
+		return nil, err
+	}
+	db.getCalDAVObject, err = sqlDB.Prepare(`
+		select shift
+		from caldav_object
+		where pad = ?
+			and name = ?`)
+	if err != nil {
+		return nil, err
+	}
+	db.getCalDAVObjects, err = sqlDB.Prepare(`
+		select shift, name
+		from caldav_object
+		where pad = ?`)
+	if err != nil {
+		return nil, err
+	}
+	db.getFreeCapacity, err = sqlDB.Prepare(`
+		select
+			shift.pad,
+			shift.quantity - (
+				select count(1)
+				from taker
+				where taker.shift = shift.id
+					and taker.rejected = false
+			),
+			-- promoted waiters are not checked for overlaps and limits, so they must be approved manually then
+			pad.waitlist_approve
+				and not pad.reject_overlaps
+				and pad.min_rest = 0
+				and pad.max_hours_day = 0
+				and pad.max_hours_week = 0
+				and pad.max_hours_month = 0
+		from shift
+		join pad on pad.id = shift.pad
+		where shift.id = ?`)
+	if err != nil {
+		return nil, err
+	}
 	db.getPad, err = sqlDB.Prepare(`
 		select
 			id,
@@ -214,13 +518,69 @@ func OpenDB(dbpath string) (*DB, error) {
 			last_updated,
 			location,
 			name,
-			shift_names
+			shift_names,
+			waitlist_approve,
+			reject_overlaps,
+			min_rest,
+			max_hours_day,
+			max_hours_week,
+			max_hours_month,
+			currency,
+			rates,
+			surcharges,
+			holidays,
+			break_rules
 		from pad
 		where id = ?
 		limit 1`)
 	if err != nil {
 		return nil, err
 	}
+	db.getPayout, err = sqlDB.Prepare(`
+		select id, created, payer, taker, hours, amount, undone
+		from payout
+		where pad = ?
+			and id = ?`)
+	if err != nil {
+		return nil, err
+	}
+	db.getPayoutSums, err = sqlDB.Prepare(`
+		select
+			taker.name,
+			shift.name,
+			shift.rate,
+			sum(taker.paid_out = true),
+			coalesce(sum(case when taker.paid_out = true then ` + workedSeconds + ` end), 0) / 3600.0,
+			sum(taker.paid_out = false),
+			coalesce(sum(case when taker.paid_out = false then ` + workedSeconds + ` end), 0) / 3600.0
+		from taker
+		join shift on shift.id = taker.shift
+		where taker.pad = ?
+			and shift.begin >= ?
+			and shift.begin < ?
+			and taker.approved = true
+			and (shift.paid = true or taker.paid_out = true)
+			and (taker.attendance != ? or taker.paid_out = true)
+		group by taker.name, shift.name, shift.rate
+		order by taker.name, shift.name, shift.rate`)
+	if err != nil {
+		return nil, err
+	}
+	db.getPayoutTakes, err = sqlDB.Prepare(`
+		select take, shift_name
+		from payout_take
+		where payout = ?`)
+	if err != nil {
+		return nil, err
+	}
+	db.getPayouts, err = sqlDB.Prepare(`
+		select id, created, payer, taker, hours, amount, undone
+		from payout
+		where pad = ?
+		order by created desc, id desc`)
+	if err != nil {
+		return nil, err
+	}
 	db.getShare, err = sqlDB.Prepare(`
 		select auth
 		from share
@@ -248,7 +608,10 @@ func OpenDB(dbpath string) (*DB, error) {
 			event,
 			quantity,
 			begin,
-			end
+			end,
+			series,
+			rate,
+			break_minutes
 		from shift
 		where pad = ?
 			and id = ?`)
@@ -265,7 +628,10 @@ func OpenDB(dbpath string) (*DB, error) {
 			event,
 			quantity,
 			begin,
-			end
+			end,
+			series,
+			rate,
+			break_minutes
 		from shift
 		where pad = ?
 			and (
@@ -286,15 +652,45 @@ func OpenDB(dbpath string) (*DB, error) {
 			event,
 			quantity,
 			begin,
-			end
+			end,
+			series,
+			rate,
+			break_minutes
 		from shift
 		where pad = ?
 			and event = ?`)
 	if err != nil {
 		return nil, err
 	}
+	db.getShiftsBySeries, err = sqlDB.Prepare(`
+		select
+			id,
+			modified,
+			name,
+			note,
+			paid,
+			event,
+			quantity,
+			begin,
+			end,
+			series,
+			rate,
+			break_minutes
+		from shift
+		where pad = ?
+			and series = ?`)
+	if err != nil {
+		return nil, err
+	}
+	db.getTakerIDs, err = sqlDB.Prepare(`
+		select id
+		from taker
+		where shift = ?`)
+	if err != nil {
+		return nil, err
+	}
 	db.getTakerNames, err = sqlDB.Prepare(`
-		select distinct taker.name
+		select distinct taker.name, shift.name
 		from shift, taker
 		where shift.id = taker.shift
 			and (shift.paid = true or taker.paid_out = true)
@@ -309,7 +705,13 @@ func OpenDB(dbpath string) (*DB, error) {
 			name,
 			contact,
 			approved,
-			paid_out
+			paid_out,
+			rejected,
+			reject_reason,
+			offered,
+			actual_begin,
+			actual_end,
+			attendance
 		from taker
 		where shift = ?
 	`)
@@ -323,7 +725,13 @@ func OpenDB(dbpath string) (*DB, error) {
 			taker.name,
 			taker.contact,
 			taker.approved,
-			taker.paid_out
+			taker.paid_out,
+			taker.rejected,
+			taker.reject_reason,
+			taker.offered,
+			taker.actual_begin,
+			taker.actual_end,
+			taker.attendance
 		from taker
 		where taker.pad = ?
 			and taker.name = ?
@@ -331,14 +739,118 @@ func OpenDB(dbpath string) (*DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	db.getWaitersByShift, err = sqlDB.Prepare(`
+		select
+			id,
+			name,
+			contact,
+			take
+		from waiter
+		where shift = ?
+		order by id`)
+	if err != nil {
+		return nil, err
+	}
+	db.offerTake, err = sqlDB.Prepare(`
+		update taker
+		set offered = ?
+		where id = ?
+			and shift = ?
+			and approved = true
+			and paid_out = false`)
+	if err != nil {
+		return nil, err
+	}
+	db.recordTake, err = sqlDB.Prepare(`
+		update taker
+		set
+			actual_begin = ?,
+			actual_end = ?
+		where id = ?
+			and shift = ?
+			and approved = true
+			and paid_out = false
+	`)
+	if err != nil {
+		return nil, err
+	}
+	db.rejectTake, err = sqlDB.Prepare(`
+		update taker
+		set
+			rejected = true,
+			reject_reason = ?
+		where id = ?
+			and shift = ?
+			and approved = false
+	`)
+	if err != nil {
+		return nil, err
+	}
+	db.setAttendance, err = sqlDB.Prepare(`
+		update taker
+		set attendance = ?
+		where id = ?
+			and shift = ?
+			and approved = true
+	`)
+	if err != nil {
+		return nil, err
+	}
+	// setPaidOut affects no row if the take has been paid out in the meantime
 	db.setPaidOut, err = sqlDB.Prepare(`
 		update taker
-		set paid_out = ?
+		set paid_out = true
 		where id = ?
+			and paid_out = false
 	`)
 	if err != nil {
 		return nil, err
 	}
+	// takeShift checks the quantity and inserts the taker in one statement, so concurrent takes can't overbook the shift
+	db.takeShift, err = sqlDB.Prepare(`
+		insert into taker (
+			pad,
+			shift,
+			name,
+			contact,
+			approved,
+			paid_out
+		)
+		select ?, ?, ?, ?, ?, ?
+		where (select count(*) from taker where shift = ? and approved = true) < (select quantity from shift where id = ?)
+	`)
+	if err != nil {
+		return nil, err
+	}
+	db.undoPayout, err = sqlDB.Prepare(`
+		update payout
+		set undone = ?
+		where pad = ?
+			and id = ?
+			and undone = 0`)
+	if err != nil {
+		return nil, err
+	}
+	// undoPayoutTakes skips takes which have been paid out again in a later payout
+	db.undoPayoutTakes, err = sqlDB.Prepare(`
+		update taker
+		set paid_out = false
+		where pad = ?
+			and id in (
+				select take
+				from payout_take
+				where payout = ?
+			)
+			and id not in (
+				select payout_take.take
+				from payout_take
+				join payout on payout.id = payout_take.payout
+				where payout.id > ?
+					and payout.undone = 0
+			)`)
+	if err != nil {
+		return nil, err
+	}
 	db.updatePad, err = sqlDB.Prepare(`
 		update pad
 		set
@@ -346,7 +858,18 @@ func OpenDB(dbpath string) (*DB, error) {
 			ical_overlay = ?,
 			location = ?,
 			name = ?,
-			shift_names = ?
+			shift_names = ?,
+			waitlist_approve = ?,
+			reject_overlaps = ?,
+			min_rest = ?,
+			max_hours_day = ?,
+			max_hours_week = ?,
+			max_hours_month = ?,
+			currency = ?,
+			rates = ?,
+			surcharges = ?,
+			holidays = ?,
+			break_rules = ?
 		where id = ?`)
 	if err != nil {
 		return nil, err
@@ -358,6 +881,14 @@ func OpenDB(dbpath string) (*DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	db.updateShare, err = sqlDB.Prepare(`
+		update share
+		set auth = ?
+		where secret = ?
+			and pad = ?`)
+	if err != nil {
+		return nil, err
+	}
 	db.updateShift, err = sqlDB.Prepare(`
 		update shift
 		set
@@ -368,11 +899,21 @@ func OpenDB(dbpath string) (*DB, error) {
 			event = ?,
 			quantity = ?,
 			begin = ?,
-			end = ?
+			end = ?,
+			rate = ?,
+			break_minutes = ?
 		where id = ?`)
 	if err != nil {
 		return nil, err
 	}
+	db.updateShiftSeries, err = sqlDB.Prepare(`
+		update shift
+		set series = ?
+		where id = ?
+	`)
+	if err != nil {
+		return nil, err
+	}
 	db.updateShiftModified, err = sqlDB.Prepare(`
 		update shift
 		set modified = ?
@@ -385,55 +926,348 @@ func OpenDB(dbpath string) (*DB, error) {
 	return db, nil
 }
 
-func (db *DB) AddPad(pad shiftpad.Pad) error {
-	shiftnames := strings.Join(pad.ShiftNames, "\n")
-	_, err := db.addPad.Exec(pad.ID, pad.Description, pad.ICalOverlay, pad.LastUpdated, pad.Location.String(), pad.Name, shiftnames)
+// addColumn adds a column to an existing table. It does nothing if the column exists already.
+func addColumn(sqlDB *sql.DB, table, column, definition string) error {
+	_, err := sqlDB.Exec(fmt.Sprintf("alter table %s add column %s %s", table, column, definition))
+	if err != nil && strings.Contains(err.Error(), "duplicate column name") {
+		return nil
+	}
 	return err
 }
 
-func (db *DB) AddShare(pad shiftpad.Pad, secret string, auth shiftpad.Auth) error {
-	_, err := db.addShare.Exec(secret, pad.ID, auth.Encode())
-	return err
+func unixOrZero(unix int64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
 }
 
-func (db *DB) AddShift(pad *shiftpad.Pad, shift shiftpad.Shift) error {
-	_, err := db.addShift.Exec(pad.ID, shift.Modified.Unix(), shift.Name, shift.Note, shift.Paid, shift.EventUID, shift.Quantity, shift.Begin.Unix(), shift.End.Unix())
-	return err
+func zeroOrUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
 }
 
-func (db *DB) ApproveTake(shift *shiftpad.Shift, take shiftpad.Take) error {
-	_, err := db.approveTake.Exec(take.ID, shift.ID)
-	return err
+// encodeRates encodes hourly rates per shift name like url query values.
+func encodeRates(rates map[string]float64) string {
+	var values = make(url.Values)
+	for name, rate := range rates {
+		values.Set(name, strconv.FormatFloat(rate, 'f', -1, 64))
+	}
+	return values.Encode()
 }
 
-func (db *DB) DeletePad(pad shiftpad.Pad) error {
-	if _, err := db.deletePad.Exec(pad.ID); err != nil {
-		return err
+func decodeRates(s string) map[string]float64 {
+	values, _ := url.ParseQuery(s) // ignore errors, rates are not critical
+	var rates = make(map[string]float64)
+	for name := range values {
+		if rate, err := strconv.ParseFloat(values.Get(name), 64); err == nil {
+			rates[name] = rate
+		}
 	}
-	_, err := db.deleteShifts.Exec(pad.ID)
-	return err
+	return rates
 }
 
-func (db *DB) DeletePads(cutoff string) error {
-	// validate cutoff
-	if cutoff == "" || cutoff > time.Now().AddDate(0, 0, -60).Format(time.DateOnly) {
-		return fmt.Errorf("invalid cutoff: %s", cutoff)
+func encodeSurcharges(surcharges []shiftpad.Surcharge) string {
+	var lines []string
+	for _, surcharge := range surcharges {
+		lines = append(lines, surcharge.String())
 	}
+	return strings.Join(lines, "\n")
+}
 
-	_, err := db.deletePads.Exec(cutoff)
-	return err
+func decodeSurcharges(s string) []shiftpad.Surcharge {
+	var surcharges []shiftpad.Surcharge
+	for _, line := range strings.Split(s, "\n") {
+		if surcharge, err := shiftpad.ParseSurcharge(line); err == nil { // ignore invalid lines, they are validated on input
+			surcharges = append(surcharges, surcharge)
+		}
+	}
+	return surcharges
 }
 
-func (db *DB) DeleteShift(shift *shiftpad.Shift) error {
-	_, err := db.deleteShift.Exec(shift.ID)
-	return err
+func encodeBreakRules(rules []shiftpad.BreakRule) string {
+	var lines []string
+	for _, rule := range rules {
+		lines = append(lines, rule.String())
+	}
+	return strings.Join(lines, "\n")
 }
 
-func (db *DB) GetAuthPad(id, secret string) (shiftpad.AuthPad, error) {
+func decodeBreakRules(s string) []shiftpad.BreakRule {
+	var rules []shiftpad.BreakRule
+	for _, line := range strings.Split(s, "\n") {
+		if rule, err := shiftpad.ParseBreakRule(line); err == nil { // ignore invalid lines, they are validated on input
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// AcceptOffer transfers an offered take to newTake.Name and newTake.Contact. It returns shiftpad.ErrOfferGone if the take is not offered any more.
+// If the new taker is on the waitlist of the shift, they are removed from it.
+func (db *DB) AcceptOffer(shift *shiftpad.Shift, take shiftpad.Take, newTake shiftpad.Take) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	result, err := tx.Stmt(db.acceptOffer).Exec(newTake.Name, newTake.Contact, take.ID, shift.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return shiftpad.ErrOfferGone
+	}
+	if _, err := tx.Stmt(db.deleteWaiterByName).Exec(shift.ID, newTake.Name); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddCalDAVShift adds a shift which a CalDAV client has created, and stores the resource name which the client has chosen.
+func (db *DB) AddCalDAVShift(pad *shiftpad.Pad, shift shiftpad.Shift, object string) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	result, err := tx.Stmt(db.addShift).Exec(pad.ID, shift.Modified.Unix(), shift.Name, shift.Note, shift.Paid, shift.EventUID, shift.Quantity, shift.Begin.Unix(), shift.End.Unix(), 0, shift.Rate, int(shift.Break.Minutes()))
+	if err != nil {
+		return err
+	}
+	shiftID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.addCalDAVObject).Exec(pad.ID, object, shiftID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *DB) AddPad(pad shiftpad.Pad) error {
+	shiftnames := strings.Join(pad.ShiftNames, "\n")
+	_, err := db.addPad.Exec(pad.ID, pad.Description, pad.ICalOverlay, pad.LastUpdated, pad.Location.String(), pad.Name, shiftnames, pad.WaitlistApprove, pad.RejectOverlaps, pad.Limits.MinRest, pad.Limits.MaxDay, pad.Limits.MaxWeek, pad.Limits.MaxMonth, pad.Currency, encodeRates(pad.Rates), encodeSurcharges(pad.Surcharges), strings.Join(pad.Holidays, "\n"), encodeBreakRules(pad.BreakRules))
+	return err
+}
+
+// AddPayout records the payout, marks its takes as paid out and returns the id of the payout. It returns shiftpad.ErrPaidOut if any of the takes has been paid out in the meantime.
+func (db *DB) AddPayout(pad *shiftpad.Pad, payout shiftpad.Payout) (int, error) {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Stmt(db.addPayout).Exec(pad.ID, payout.Created.Unix(), payout.Payer, payout.Taker, payout.Hours, payout.Amount)
+	if err != nil {
+		return 0, err
+	}
+	payoutID, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	for _, take := range payout.Takes {
+		if _, err := tx.Stmt(db.addPayoutTake).Exec(payoutID, take.TakeID, take.ShiftName); err != nil {
+			return 0, err
+		}
+		result, err := tx.Stmt(db.setPaidOut).Exec(take.TakeID)
+		if err != nil {
+			return 0, err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return 0, err
+		} else if n == 0 {
+			return 0, shiftpad.ErrPaidOut // rolls back the whole payout
+		}
+	}
+	return int(payoutID), tx.Commit()
+}
+
+func (db *DB) AddShare(pad shiftpad.Pad, secret string, auth shiftpad.Auth) error {
+	_, err := db.addShare.Exec(secret, pad.ID, auth.Encode())
+	return err
+}
+
+func (db *DB) AddShift(pad *shiftpad.Pad, shift shiftpad.Shift) error {
+	_, err := db.addShift.Exec(pad.ID, shift.Modified.Unix(), shift.Name, shift.Note, shift.Paid, shift.EventUID, shift.Quantity, shift.Begin.Unix(), shift.End.Unix(), 0, shift.Rate, int(shift.Break.Minutes()))
+	return err
+}
+
+// AddShifts adds the given shifts in a single transaction.
+func (db *DB) AddShifts(pad *shiftpad.Pad, shifts []shiftpad.Shift) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, shift := range shifts {
+		if _, err := tx.Stmt(db.addShift).Exec(pad.ID, shift.Modified.Unix(), shift.Name, shift.Note, shift.Paid, shift.EventUID, shift.Quantity, shift.Begin.Unix(), shift.End.Unix(), 0, shift.Rate, int(shift.Break.Minutes())); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// AddSeries adds the given shifts as a series. The series id is the id of the first shift.
+func (db *DB) AddSeries(pad *shiftpad.Pad, shifts []shiftpad.Shift) error {
+	if len(shifts) == 0 {
+		return nil
+	}
+
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var seriesID int64
+	for i, shift := range shifts {
+		result, err := tx.Stmt(db.addShift).Exec(pad.ID, shift.Modified.Unix(), shift.Name, shift.Note, shift.Paid, shift.EventUID, shift.Quantity, shift.Begin.Unix(), shift.End.Unix(), seriesID, shift.Rate, int(shift.Break.Minutes()))
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			seriesID, err = result.LastInsertId()
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Stmt(db.updateShiftSeries).Exec(seriesID, seriesID); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *DB) AddWaiter(shift *shiftpad.Shift, waiter shiftpad.Waiter) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Stmt(db.addWaiter).Exec(shift.ID, waiter.Name, waiter.Contact, waiter.Take); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ApproveTake returns shiftpad.ErrFullyTaken if the shift has no capacity left.
+func (db *DB) ApproveTake(shift *shiftpad.Shift, take shiftpad.Take) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	result, err := tx.Stmt(db.approveTake).Exec(take.ID, shift.ID, shift.ID, shift.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return shiftpad.ErrFullyTaken
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *DB) CancelTake(shift *shiftpad.Shift, take shiftpad.Take) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Stmt(db.detachPayoutTake).Exec(take.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.deleteTaker).Exec(take.ID, shift.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	if err := db.promoteWaiters(tx, shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *DB) DeletePad(pad shiftpad.Pad) error {
+	if _, err := db.deletePad.Exec(pad.ID); err != nil {
+		return err
+	}
+	_, err := db.deleteShifts.Exec(pad.ID)
+	return err
+}
+
+func (db *DB) DeletePads(cutoff string) error {
+	// validate cutoff
+	if cutoff == "" || cutoff > time.Now().AddDate(0, 0, -60).Format(time.DateOnly) {
+		return fmt.Errorf("invalid cutoff: %s", cutoff)
+	}
+
+	_, err := db.deletePads.Exec(cutoff)
+	return err
+}
+
+func (db *DB) DeleteShare(pad *shiftpad.Pad, secret string) error {
+	_, err := db.deleteShare.Exec(secret, pad.ID)
+	return err
+}
+
+func (db *DB) DeleteShift(shift *shiftpad.Shift) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Stmt(db.detachPayoutTakes).Exec(shift.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.deleteShift).Exec(shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *DB) DeleteWaiter(shift *shiftpad.Shift, waiter shiftpad.Waiter) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Stmt(db.deleteWaiter).Exec(waiter.ID, shift.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *DB) GetAuthPad(id, secret string) (shiftpad.AuthPad, error) {
 	var pad = &shiftpad.Pad{}
 	var location string
 	var shiftnames string
-	if err := db.getPad.QueryRow(id).Scan(&pad.ID, &pad.Description, &pad.ICalOverlay, &pad.LastUpdated, &location, &pad.Name, &shiftnames); err != nil {
+	var rates string
+	var surcharges string
+	var holidays string
+	var breakRules string
+	if err := db.getPad.QueryRow(id).Scan(&pad.ID, &pad.Description, &pad.ICalOverlay, &pad.LastUpdated, &location, &pad.Name, &shiftnames, &pad.WaitlistApprove, &pad.RejectOverlaps, &pad.Limits.MinRest, &pad.Limits.MaxDay, &pad.Limits.MaxWeek, &pad.Limits.MaxMonth, &pad.Currency, &rates, &surcharges, &holidays, &breakRules); err != nil {
 		return shiftpad.AuthPad{}, err
 	}
 	loc, err := time.LoadLocation(location)
@@ -442,6 +1276,10 @@ func (db *DB) GetAuthPad(id, secret string) (shiftpad.AuthPad, error) {
 	}
 	pad.Location = loc
 	pad.ShiftNames = strings.FieldsFunc(shiftnames, func(r rune) bool { return r == '\r' || r == '\n' })
+	pad.Rates = decodeRates(rates)
+	pad.Surcharges = decodeSurcharges(surcharges)
+	pad.Holidays = strings.Fields(holidays)
+	pad.BreakRules = decodeBreakRules(breakRules)
 
 	var authstr string
 	if err := db.getShare.QueryRow(secret, pad.ID).Scan(&authstr); err != nil {
@@ -461,6 +1299,126 @@ func (db *DB) GetAuthPad(id, secret string) (shiftpad.AuthPad, error) {
 	}, nil
 }
 
+// GetCalDAVShift returns the shift which a CalDAV client has created with the given resource name.
+func (db *DB) GetCalDAVShift(pad *shiftpad.Pad, object string) (*shiftpad.Shift, error) {
+	var id int
+	if err := db.getCalDAVObject.QueryRow(pad.ID, object).Scan(&id); err != nil {
+		return nil, err
+	}
+	return db.GetShift(pad, id)
+}
+
+// GetCalDAVObjects returns the resource names of the shifts which CalDAV clients have created, by shift id.
+func (db *DB) GetCalDAVObjects(pad *shiftpad.Pad) (map[int]string, error) {
+	rows, err := db.getCalDAVObjects.Query(pad.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var objects = make(map[int]string)
+	for rows.Next() {
+		var shift int
+		var name string
+		if err := rows.Scan(&shift, &name); err != nil {
+			return nil, err
+		}
+		objects[shift] = name
+	}
+	return objects, rows.Err()
+}
+
+func (db *DB) GetPayout(pad *shiftpad.Pad, id int) (shiftpad.Payout, error) {
+	payout, err := db.readPayout(pad, db.getPayout.QueryRow(pad.ID, id))
+	if err != nil {
+		return shiftpad.Payout{}, err
+	}
+	payout.Takes, err = db.getTakesOfPayout(payout.ID)
+	return payout, err
+}
+
+// GetPayoutSums returns the payout sums of the approved takes in shifts which begin in [from, to), per taker, shift name and shift rate.
+// No-show takes are skipped unless they have been paid out.
+func (db *DB) GetPayoutSums(pad *shiftpad.Pad, from, to int64) ([]shiftpad.PayoutSum, error) {
+	rows, err := db.getPayoutSums.Query(pad.ID, from, to, shiftpad.NoShow)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sums []shiftpad.PayoutSum
+	for rows.Next() {
+		var sum shiftpad.PayoutSum
+		if err := rows.Scan(&sum.Taker, &sum.ShiftName, &sum.Rate, &sum.PaidOutTakes, &sum.PaidOutHours, &sum.OutstandingTakes, &sum.OutstandingHours); err != nil {
+			return nil, err
+		}
+		sums = append(sums, sum)
+	}
+	return sums, rows.Err()
+}
+
+// GetPayouts returns the payouts of the pad, latest first.
+func (db *DB) GetPayouts(pad *shiftpad.Pad) ([]shiftpad.Payout, error) {
+	rows, err := db.getPayouts.Query(pad.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payouts []shiftpad.Payout
+	for rows.Next() {
+		payout, err := db.readPayout(pad, rows)
+		if err != nil {
+			return nil, err
+		}
+		payouts = append(payouts, payout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range payouts {
+		payouts[i].Takes, err = db.getTakesOfPayout(payouts[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return payouts, nil
+}
+
+func (db *DB) readPayout(pad *shiftpad.Pad, row interface{ Scan(...any) error }) (shiftpad.Payout, error) {
+	var payout shiftpad.Payout
+	var created int64
+	var undone int64
+	if err := row.Scan(&payout.ID, &created, &payout.Payer, &payout.Taker, &payout.Hours, &payout.Amount, &undone); err != nil {
+		return shiftpad.Payout{}, err
+	}
+	payout.Created = time.Unix(created, 0).In(pad.Location)
+	if undone != 0 {
+		payout.UndoneTime = time.Unix(undone, 0).In(pad.Location)
+	}
+	return payout, nil
+}
+
+func (db *DB) getTakesOfPayout(payout int) ([]shiftpad.PayoutTake, error) {
+	rows, err := db.getPayoutTakes.Query(payout)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var takes []shiftpad.PayoutTake
+	for rows.Next() {
+		var take shiftpad.PayoutTake
+		if err := rows.Scan(&take.TakeID, &take.ShiftName); err != nil {
+			return nil, err
+		}
+		takes = append(takes, take)
+	}
+	return takes, rows.Err()
+}
+
 func (db *DB) GetShares(pad *shiftpad.Pad) ([]shiftpad.Share, error) {
 	rows, err := db.getShares.Query(pad.ID)
 	if err != nil {
@@ -496,12 +1454,14 @@ func (db *DB) readShift(pad *shiftpad.Pad, id int, loadTakers bool) (*shiftpad.S
 	var modified int64
 	var begin int64
 	var end int64
-	if err := db.getShift.QueryRow(pad.ID, id).Scan(&shift.ID, &modified, &shift.Name, &shift.Note, &shift.Paid, &shift.EventUID, &shift.Quantity, &begin, &end); err != nil {
+	var breakMinutes int
+	if err := db.getShift.QueryRow(pad.ID, id).Scan(&shift.ID, &modified, &shift.Name, &shift.Note, &shift.Paid, &shift.EventUID, &shift.Quantity, &begin, &end, &shift.SeriesID, &shift.Rate, &breakMinutes); err != nil {
 		return nil, err
 	}
 	shift.Modified = time.Unix(modified, 0).In(pad.Location)
 	shift.Begin = time.Unix(begin, 0).In(pad.Location)
 	shift.End = time.Unix(end, 0).In(pad.Location)
+	shift.Break = time.Duration(breakMinutes) * time.Minute
 
 	if loadTakers {
 		if takes, err := db.GetTakersByShift(shift.ID); err == nil {
@@ -509,6 +1469,11 @@ func (db *DB) readShift(pad *shiftpad.Pad, id int, loadTakers bool) (*shiftpad.S
 		} else {
 			return nil, err
 		}
+		if waiters, err := db.GetWaitersByShift(shift.ID); err == nil {
+			shift.Waitlist = waiters
+		} else {
+			return nil, err
+		}
 	}
 
 	return shift, nil
@@ -522,6 +1487,11 @@ func (db *DB) GetShiftsByEvent(pad *shiftpad.Pad, eventUID string) ([]shiftpad.S
 	return db.readShifts(pad.Location, db.getShiftsByEvent, pad.ID, eventUID)
 }
 
+// GetShiftsBySeries returns all shifts of a series, including the given one.
+func (db *DB) GetShiftsBySeries(pad *shiftpad.Pad, seriesID int) ([]shiftpad.Shift, error) {
+	return db.readShifts(pad.Location, db.getShiftsBySeries, pad.ID, seriesID)
+}
+
 func (db *DB) readShifts(location *time.Location, stmt *sql.Stmt, args ...any) ([]shiftpad.Shift, error) {
 	rows, err := stmt.Query(args...)
 	if err != nil {
@@ -535,25 +1505,32 @@ func (db *DB) readShifts(location *time.Location, stmt *sql.Stmt, args ...any) (
 		var modified int64
 		var begin int64
 		var end int64
-		if err := rows.Scan(&shift.ID, &modified, &shift.Name, &shift.Note, &shift.Paid, &shift.EventUID, &shift.Quantity, &begin, &end); err != nil {
+		var breakMinutes int
+		if err := rows.Scan(&shift.ID, &modified, &shift.Name, &shift.Note, &shift.Paid, &shift.EventUID, &shift.Quantity, &begin, &end, &shift.SeriesID, &shift.Rate, &breakMinutes); err != nil {
 			return nil, err
 		}
 		shift.Modified = time.Unix(modified, 0).In(location)
 		shift.Begin = time.Unix(begin, 0).In(location)
 		shift.End = time.Unix(end, 0).In(location)
+		shift.Break = time.Duration(breakMinutes) * time.Minute
 
 		if takes, err := db.GetTakersByShift(shift.ID); err == nil {
 			shift.Takes = takes
 		} else {
 			return nil, err
 		}
+		if waiters, err := db.GetWaitersByShift(shift.ID); err == nil {
+			shift.Waitlist = waiters
+		} else {
+			return nil, err
+		}
 
 		shifts = append(shifts, shift)
 	}
 	return shifts, nil
 }
 
-// returned shifts contain only takes with the given taker name
+// returned shifts are sorted by begin and contain only takes with the given taker name
 func (db *DB) GetTakesByTaker(pad *shiftpad.Pad, name string) ([]shiftpad.Shift, error) {
 	rows, err := db.getTakesByName.Query(pad.ID, name)
 	if err != nil {
@@ -565,9 +1542,12 @@ func (db *DB) GetTakesByTaker(pad *shiftpad.Pad, name string) ([]shiftpad.Shift,
 	for rows.Next() {
 		var take shiftpad.Take
 		var shiftID int
-		if err := rows.Scan(&take.ID, &shiftID, &take.Name, &take.Contact, &take.Approved, &take.PaidOut); err != nil {
+		var actualBegin, actualEnd int64
+		if err := rows.Scan(&take.ID, &shiftID, &take.Name, &take.Contact, &take.Approved, &take.PaidOut, &take.Rejected, &take.RejectReason, &take.Offered, &actualBegin, &actualEnd, &take.Attendance); err != nil {
 			return nil, err
 		}
+		take.ActualBegin = unixOrZero(actualBegin)
+		take.ActualEnd = unixOrZero(actualEnd)
 		takes[shiftID] = append(takes[shiftID], take)
 	}
 
@@ -580,10 +1560,14 @@ func (db *DB) GetTakesByTaker(pad *shiftpad.Pad, name string) ([]shiftpad.Shift,
 		shift.Takes = takes[shift.ID]
 		shifts = append(shifts, *shift)
 	}
+	slices.SortFunc(shifts, func(a, b shiftpad.Shift) int {
+		return a.Begin.Compare(b.Begin)
+	})
 	return shifts, nil
 }
 
-func (db *DB) GetTakerNames(pad *shiftpad.Pad) ([]string, error) {
+// GetTakerNames returns the names of takers which have takes of paid shifts or paid out takes, in shifts whose name is accepted by the shiftname func.
+func (db *DB) GetTakerNames(pad *shiftpad.Pad, shiftname func(string) bool) ([]string, error) {
 	rows, err := db.getTakerNames.Query(pad.ID)
 	if err != nil {
 		return nil, err
@@ -592,11 +1576,13 @@ func (db *DB) GetTakerNames(pad *shiftpad.Pad) ([]string, error) {
 
 	var takerNames []string
 	for rows.Next() {
-		var takerName string
-		if err := rows.Scan(&takerName); err != nil {
+		var takerName, shiftName string
+		if err := rows.Scan(&takerName, &shiftName); err != nil {
 			return nil, err
 		}
-		takerNames = append(takerNames, takerName)
+		if shiftname(shiftName) && !slices.Contains(takerNames, takerName) {
+			takerNames = append(takerNames, takerName)
+		}
 	}
 	return takerNames, nil
 }
@@ -611,40 +1597,189 @@ func (db *DB) GetTakersByShift(shift int) ([]shiftpad.Take, error) {
 	var takes []shiftpad.Take
 	for rows.Next() {
 		var take shiftpad.Take
-		if err := rows.Scan(&take.ID, &take.Name, &take.Contact, &take.Approved, &take.PaidOut); err != nil {
+		var actualBegin, actualEnd int64
+		if err := rows.Scan(&take.ID, &take.Name, &take.Contact, &take.Approved, &take.PaidOut, &take.Rejected, &take.RejectReason, &take.Offered, &actualBegin, &actualEnd, &take.Attendance); err != nil {
 			return nil, err
 		}
+		take.ActualBegin = unixOrZero(actualBegin)
+		take.ActualEnd = unixOrZero(actualEnd)
 		takes = append(takes, take)
 	}
 	return takes, nil
 }
 
-// SetPaidOut writes take.PaidOut to the database.
-// Alternatively, we could delete and re-add the takes.
-func (db *DB) SetPaidOut(takes []shiftpad.Take) error {
+func (db *DB) GetWaitersByShift(shift int) ([]shiftpad.Waiter, error) {
+	rows, err := db.getWaitersByShift.Query(shift)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var waiters []shiftpad.Waiter
+	for rows.Next() {
+		var waiter shiftpad.Waiter
+		if err := rows.Scan(&waiter.ID, &waiter.Name, &waiter.Contact, &waiter.Take); err != nil {
+			return nil, err
+		}
+		waiters = append(waiters, waiter)
+	}
+	return waiters, nil
+}
+
+// OfferTake sets or unsets the handover offer of an approved take which has not been paid out.
+func (db *DB) OfferTake(shift *shiftpad.Shift, take shiftpad.Take, offered bool) error {
 	tx, err := db.SQLDB.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
+	if _, err := tx.Stmt(db.offerTake).Exec(offered, take.ID, shift.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// promoteWaiters moves waiters to takes as long as the shift has capacity left. Pending applications count as capacity used.
+// Waiters become approved takes if they joined with take permission and the pad has WaitlistApprove set, else they become applications.
+// If the pad rejects overlaps or has limits, waiters always become applications, because the checks of the take handlers are not run here.
+func (db *DB) promoteWaiters(tx *sql.Tx, shift int) error {
+	var padID string
+	var free int
+	var approve bool
+	if err := tx.Stmt(db.getFreeCapacity).QueryRow(shift).Scan(&padID, &free, &approve); err != nil {
+		return err
+	}
+	if free <= 0 {
+		return nil
+	}
 
-	for _, take := range takes {
-		if _, err := tx.Stmt(db.setPaidOut).Exec(take.PaidOut, take.ID); err != nil {
+	rows, err := tx.Stmt(db.getWaitersByShift).Query(shift)
+	if err != nil {
+		return err
+	}
+	var waiters []shiftpad.Waiter
+	for len(waiters) < free && rows.Next() {
+		var waiter shiftpad.Waiter
+		if err := rows.Scan(&waiter.ID, &waiter.Name, &waiter.Contact, &waiter.Take); err != nil {
+			rows.Close()
+			return err
+		}
+		waiters = append(waiters, waiter)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, waiter := range waiters {
+		if _, err := tx.Stmt(db.addTaker).Exec(padID, shift, waiter.Name, waiter.Contact, approve && waiter.Take, false, false, "", false); err != nil {
+			return err
+		}
+		if _, err := tx.Stmt(db.deleteWaiter).Exec(waiter.ID, shift); err != nil {
+			return err
+		}
+	}
+	if len(waiters) > 0 {
+		if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift); err != nil {
 			return err
 		}
 	}
+	return nil
+}
+
+// RecordTake sets the actual begin and end of an approved take which has not been paid out. Zero times reset them.
+func (db *DB) RecordTake(shift *shiftpad.Shift, take shiftpad.Take) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Stmt(db.recordTake).Exec(zeroOrUnix(take.ActualBegin), zeroOrUnix(take.ActualEnd), take.ID, shift.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RejectTake rejects an application. The freed capacity is offered to the waitlist.
+func (db *DB) RejectTake(shift *shiftpad.Shift, take shiftpad.Take) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Stmt(db.rejectTake).Exec(take.RejectReason, take.ID, shift.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	if err := db.promoteWaiters(tx, shift.ID); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
+// SetAttendance sets the attendance of an approved take.
+func (db *DB) SetAttendance(shift *shiftpad.Shift, take shiftpad.Take) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Stmt(db.setAttendance).Exec(take.Attendance, take.ID, shift.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UndoPayout marks the payout as undone and resets PaidOut of its takes. It returns shiftpad.ErrPayoutUndone if the payout has already been undone.
+func (db *DB) UndoPayout(pad *shiftpad.Pad, payout shiftpad.Payout) error {
+	tx, err := db.SQLDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Stmt(db.undoPayout).Exec(time.Now().Unix(), pad.ID, payout.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return shiftpad.ErrPayoutUndone
+	}
+	if _, err := tx.Stmt(db.undoPayoutTakes).Exec(pad.ID, payout.ID, payout.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// TakeShift returns shiftpad.ErrFullyTaken if the approved takes have reached the shift quantity in the meantime.
 func (db *DB) TakeShift(pad *shiftpad.Pad, shift *shiftpad.Shift, take shiftpad.Take) error {
 	tx, err := db.SQLDB.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
-	if _, err := tx.Stmt(db.addTaker).Exec(pad.ID, shift.ID, take.Name, take.Contact, take.Approved, take.PaidOut); err != nil {
+	result, err := tx.Stmt(db.takeShift).Exec(pad.ID, shift.ID, take.Name, take.Contact, take.Approved, take.PaidOut, shift.ID, shift.ID)
+	if err != nil {
 		return err
 	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return shiftpad.ErrFullyTaken
+	}
 	if _, err := tx.Stmt(db.updateShiftModified).Exec(time.Now().Unix(), shift.ID); err != nil {
 		return err
 	}
@@ -653,7 +1788,7 @@ func (db *DB) TakeShift(pad *shiftpad.Pad, shift *shiftpad.Shift, take shiftpad.
 
 func (db *DB) UpdatePad(pad *shiftpad.Pad) error {
 	shiftnames := strings.Join(pad.ShiftNames, "\n")
-	_, err := db.updatePad.Exec(pad.Description, pad.ICalOverlay, pad.Location.String(), pad.Name, shiftnames, pad.ID)
+	_, err := db.updatePad.Exec(pad.Description, pad.ICalOverlay, pad.Location.String(), pad.Name, shiftnames, pad.WaitlistApprove, pad.RejectOverlaps, pad.Limits.MinRest, pad.Limits.MaxDay, pad.Limits.MaxWeek, pad.Limits.MaxMonth, pad.Currency, encodeRates(pad.Rates), encodeSurcharges(pad.Surcharges), strings.Join(pad.Holidays, "\n"), encodeBreakRules(pad.BreakRules), pad.ID)
 	return err
 }
 
@@ -662,6 +1797,40 @@ func (db *DB) UpdatePadLastUpdated(pad *shiftpad.Pad, lastUpdated string) error
 	return err
 }
 
+func (db *DB) UpdateShare(pad *shiftpad.Pad, share shiftpad.Share) error {
+	_, err := db.updateShare.Exec(share.Auth.Encode(), share.Secret, pad.ID)
+	return err
+}
+
+// detachDeletedTakes detaches the payout ledger from the takes which are removed from the shift, because their ids might be reused.
+func (db *DB) detachDeletedTakes(tx *sql.Tx, shift *shiftpad.Shift) error {
+	rows, err := tx.Stmt(db.getTakerIDs).Query(shift.ID)
+	if err != nil {
+		return err
+	}
+	var deleted []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		if !slices.ContainsFunc(shift.Takes, func(take shiftpad.Take) bool { return take.ID == id }) {
+			deleted = append(deleted, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, id := range deleted {
+		if _, err := tx.Stmt(db.detachPayoutTake).Exec(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (db *DB) UpdateShift(pad *shiftpad.Pad, shift *shiftpad.Shift) error {
 	tx, err := db.SQLDB.Begin()
 	if err != nil {
@@ -669,7 +1838,10 @@ func (db *DB) UpdateShift(pad *shiftpad.Pad, shift *shiftpad.Shift) error {
 	}
 	defer tx.Rollback()
 
-	if _, err := tx.Stmt(db.updateShift).Exec(shift.Modified.Unix(), shift.Name, shift.Note, shift.Paid, shift.EventUID, shift.Quantity, shift.Begin.Unix(), shift.End.Unix(), shift.ID); err != nil {
+	if _, err := tx.Stmt(db.updateShift).Exec(shift.Modified.Unix(), shift.Name, shift.Note, shift.Paid, shift.EventUID, shift.Quantity, shift.Begin.Unix(), shift.End.Unix(), shift.Rate, int(shift.Break.Minutes()), shift.ID); err != nil {
+		return err
+	}
+	if err := db.detachDeletedTakes(tx, shift); err != nil {
 		return err
 	}
 	if _, err := tx.Stmt(db.deleteTakers).Exec(shift.ID); err != nil {
@@ -677,14 +1849,17 @@ func (db *DB) UpdateShift(pad *shiftpad.Pad, shift *shiftpad.Shift) error {
 	}
 	for _, take := range shift.Takes {
 		if take.ID > 0 {
-			_, err = tx.Stmt(db.addTakerWithID).Exec(take.ID, pad.ID, shift.ID, take.Name, take.Contact, take.Approved, take.PaidOut)
+			_, err = tx.Stmt(db.addTakerWithID).Exec(take.ID, pad.ID, shift.ID, take.Name, take.Contact, take.Approved, take.PaidOut, take.Rejected, take.RejectReason, take.Offered, zeroOrUnix(take.ActualBegin), zeroOrUnix(take.ActualEnd), take.Attendance)
 		} else {
-			_, err = tx.Stmt(db.addTaker).Exec(pad.ID, shift.ID, take.Name, take.Contact, take.Approved, take.PaidOut)
+			_, err = tx.Stmt(db.addTaker).Exec(pad.ID, shift.ID, take.Name, take.Contact, take.Approved, take.PaidOut, take.Rejected, take.RejectReason, take.Offered)
 		}
 		if err != nil {
 			return err
 		}
 	}
+	if err := db.promoteWaiters(tx, shift.ID); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
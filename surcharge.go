@@ -0,0 +1,184 @@
+package shiftpad
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdayAbbrs = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"} // index is time.Weekday
+
+// Surcharge is a rule for extra pay. It applies to the hours within the time window on the given weekdays and holidays.
+// If neither weekdays nor holidays are given, it applies on every day. If no window is given, it applies all day.
+//
+// Surcharges are written like "Night 25% 22:00-06:00", "Sunday 50% Sun" or "Holiday 100% holidays".
+type Surcharge struct {
+	Name     string
+	Percent  float64
+	Weekdays []time.Weekday
+	Holidays bool
+	From     int // minutes after midnight
+	To       int // minutes after midnight, window wraps around midnight if To <= From, zero From and To means all day
+}
+
+func ParseSurcharge(s string) (Surcharge, error) {
+	var surcharge Surcharge
+	fields := strings.Fields(s)
+	var i int
+	for i = 0; i < len(fields); i++ {
+		if percent, ok := strings.CutSuffix(fields[i], "%"); ok {
+			p, err := strconv.ParseFloat(percent, 64)
+			if err != nil || p <= 0 {
+				return Surcharge{}, fmt.Errorf("invalid percentage: %s", fields[i])
+			}
+			surcharge.Percent = p
+			break
+		}
+	}
+	if i == 0 || i == len(fields) {
+		return Surcharge{}, errors.New("missing name or percentage")
+	}
+	surcharge.Name = strings.Join(fields[:i], " ")
+
+	for _, field := range fields[i+1:] {
+		if weekday := slices.IndexFunc(weekdayAbbrs, func(abbr string) bool { return strings.EqualFold(abbr, field) }); weekday >= 0 {
+			surcharge.Weekdays = append(surcharge.Weekdays, time.Weekday(weekday))
+			continue
+		}
+		if strings.EqualFold(field, "holidays") {
+			surcharge.Holidays = true
+			continue
+		}
+		if from, to, ok := strings.Cut(field, "-"); ok {
+			var err1, err2 error
+			surcharge.From, err1 = parseClock(from)
+			surcharge.To, err2 = parseClock(to)
+			if err1 == nil && err2 == nil {
+				continue
+			}
+		}
+		return Surcharge{}, fmt.Errorf("invalid weekday, holidays or time window: %s", field)
+	}
+	return surcharge, nil
+}
+
+// parseClock parses "hh:mm" into minutes after midnight. "24:00" is allowed.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err == nil {
+		return t.Hour()*60 + t.Minute(), nil
+	}
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	return 0, err
+}
+
+func (surcharge Surcharge) String() string {
+	var fields = []string{surcharge.Name, strconv.FormatFloat(surcharge.Percent, 'f', -1, 64) + "%"}
+	for _, weekday := range surcharge.Weekdays {
+		fields = append(fields, weekdayAbbrs[weekday])
+	}
+	if surcharge.Holidays {
+		fields = append(fields, "holidays")
+	}
+	if surcharge.From != 0 || surcharge.To != 0 {
+		fields = append(fields, fmt.Sprintf("%02d:%02d-%02d:%02d", surcharge.From/60, surcharge.From%60, surcharge.To/60, surcharge.To%60))
+	}
+	return strings.Join(fields, " ")
+}
+
+// appliesOn returns true if the surcharge applies on the day. Holidays are formatted as yyyy-mm-dd.
+func (surcharge Surcharge) appliesOn(day time.Time, holidays []string) bool {
+	if len(surcharge.Weekdays) == 0 && !surcharge.Holidays {
+		return true
+	}
+	if slices.Contains(surcharge.Weekdays, day.Weekday()) {
+		return true
+	}
+	return surcharge.Holidays && slices.Contains(holidays, day.Format(time.DateOnly))
+}
+
+// Hours returns the hours between begin and end to which the surcharge applies.
+// Days and windows are calculated in the given location, so a night window on a day with a DST change is one hour shorter or longer.
+func (surcharge Surcharge) Hours(begin, end time.Time, loc *time.Location, holidays []string) float64 {
+	begin = begin.In(loc)
+	var sum time.Duration
+	for day := time.Date(begin.Year(), begin.Month(), begin.Day(), 0, 0, 0, 0, loc); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !surcharge.appliesOn(day, holidays) {
+			continue
+		}
+		at := func(minutes int) time.Time {
+			return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, loc)
+		}
+		switch {
+		case surcharge.From == 0 && surcharge.To == 0:
+			sum += overlapDuration(begin, end, at(0), at(24*60))
+		case surcharge.From < surcharge.To:
+			sum += overlapDuration(begin, end, at(surcharge.From), at(surcharge.To))
+		default: // wraps around midnight, the early part belongs to the same day
+			sum += overlapDuration(begin, end, at(0), at(surcharge.To))
+			sum += overlapDuration(begin, end, at(surcharge.From), at(24*60))
+		}
+	}
+	return sum.Hours()
+}
+
+// overlapDuration returns the duration of the intersection of [aBegin, aEnd) and [bBegin, bEnd).
+func overlapDuration(aBegin, aEnd, bBegin, bEnd time.Time) time.Duration {
+	begin := aBegin
+	if bBegin.After(begin) {
+		begin = bBegin
+	}
+	end := aEnd
+	if bEnd.Before(end) {
+		end = bEnd
+	}
+	if !end.After(begin) {
+		return 0
+	}
+	return end.Sub(begin)
+}
+
+// SurchargeHours are the hours of a shift or of several shifts to which a surcharge applies.
+type SurchargeHours struct {
+	Surcharge
+	Hours  float64
+	Amount float64 // extra amount, zero if no rate is set
+}
+
+// SurchargeHours returns the net hours of the shift to which each surcharge of the pad applies. Surcharges with zero hours are omitted.
+// The unpaid break has no fixed time, so it is deducted proportionally from the hours of each surcharge.
+func (pad Pad) SurchargeHours(shift Shift) []SurchargeHours {
+	var result []SurchargeHours
+	for _, surcharge := range pad.Surcharges {
+		hours := surcharge.Hours(shift.Begin, shift.End, pad.Location, pad.Holidays)
+		if gross := shift.End.Sub(shift.Begin).Hours(); gross > 0 {
+			hours *= shift.Hours() / gross
+		}
+		if hours > 0 {
+			result = append(result, SurchargeHours{
+				Surcharge: surcharge,
+				Hours:     hours,
+				Amount:    Amount(hours, pad.Rate(shift)*surcharge.Percent/100),
+			})
+		}
+	}
+	return result
+}
+
+// AddSurchargeHours adds more to sums, merging entries with the same surcharge name.
+func AddSurchargeHours(sums []SurchargeHours, more []SurchargeHours) []SurchargeHours {
+	for _, m := range more {
+		if i := slices.IndexFunc(sums, func(s SurchargeHours) bool { return s.Name == m.Name }); i >= 0 {
+			sums[i].Hours += m.Hours
+			sums[i].Amount += m.Amount
+		} else {
+			sums = append(sums, m)
+		}
+	}
+	return sums
+}
@@ -0,0 +1,77 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSurcharge(t *testing.T) {
+	for _, s := range []string{"Night 25% 22:00-06:00", "Sunday work 50% Sun", "Holiday 100% Sat holidays 00:00-24:00"} {
+		surcharge, err := ParseSurcharge(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if got := surcharge.String(); got != s {
+			t.Fatalf("got %q, want %q", got, s)
+		}
+	}
+	for _, s := range []string{"", "Night", "25% 22:00-06:00", "Night -5%", "Night 25% 22:00", "Night 25% Sunday"} {
+		if _, err := ParseSurcharge(s); err == nil {
+			t.Fatalf("%q: got no error", s)
+		}
+	}
+}
+
+func TestSurchargeHours(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, time.March, day, hour, 0, 0, 0, berlin) // 2025-03-30 is a Sunday with a DST change
+	}
+	night, _ := ParseSurcharge("Night 25% 22:00-06:00")
+	sunday, _ := ParseSurcharge("Sunday 50% Sun")
+	holiday, _ := ParseSurcharge("Holiday 100% holidays")
+	holidays := []string{"2025-03-28"}
+
+	tests := []struct {
+		surcharge  Surcharge
+		begin, end time.Time
+		want       float64
+	}{
+		{night, at(27, 20), at(28, 8), 8},
+		{night, at(29, 20), at(30, 8), 7}, // 02:00 to 03:00 does not exist
+		{night, at(27, 8), at(27, 16), 0},
+		{sunday, at(29, 20), at(30, 8), 7},
+		{sunday, at(30, 20), at(31, 8), 4},
+		{holiday, at(27, 20), at(28, 8), 8},
+		{holiday, at(28, 20), at(29, 8), 4},
+	}
+	for i, test := range tests {
+		if got := test.surcharge.Hours(test.begin, test.end, berlin, holidays); got != test.want {
+			t.Fatalf("test %d: got %v hours, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestPadSurchargeHours(t *testing.T) {
+	night, _ := ParseSurcharge("Night 25% 22:00-06:00")
+	pad := Pad{Location: time.UTC, Rates: map[string]float64{"bar": 10}, Surcharges: []Surcharge{night}}
+	begin := time.Date(2025, time.March, 27, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		shift         Shift
+		hours, amount float64
+	}{
+		{Shift{Name: "bar", Begin: begin, End: begin.Add(8 * time.Hour)}, 8, 20},
+		{Shift{Name: "bar", Begin: begin, End: begin.Add(8 * time.Hour), Break: 30 * time.Minute}, 7.5, 18.75},
+		{Shift{Name: "bar", Begin: begin.Add(-4 * time.Hour), End: begin.Add(4 * time.Hour), Break: time.Hour}, 3.5, 8.75}, // half of the shift is at night
+	}
+	for i, test := range tests {
+		got := pad.SurchargeHours(test.shift)
+		if len(got) != 1 || got[0].Hours != test.hours || got[0].Amount != test.amount {
+			t.Fatalf("test %d: got %+v, want %v hours and amount %v", i, got, test.hours, test.amount)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package shiftpad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+// VTimezone returns a VTIMEZONE component with the UTC offsets of loc between from and to. Its TZID is the name of loc.
+// The time package does not expose the transitions of a location, so they are searched.
+func VTimezone(loc *time.Location, from, to time.Time) *ical.Component {
+	tz := ical.NewComponent(ical.CompTimezone)
+	tz.Props.SetText(ical.PropTimezoneID, loc.String())
+
+	from = from.In(loc).Truncate(time.Second) // for the binary search in nextTransition
+
+	tz.Children = append(tz.Children, observance(from, from)) // offset at the begin of the range
+	for t := from; ; {
+		t = nextTransition(t, to)
+		if t.IsZero() {
+			break
+		}
+		tz.Children = append(tz.Children, observance(t.Add(-time.Second), t))
+	}
+	return tz
+}
+
+// nextTransition returns the first time after t and before end at which the UTC offset changes, or the zero time if there is none.
+func nextTransition(t, end time.Time) time.Time {
+	_, offset := t.Zone()
+	for t.Before(end) {
+		next := t.Add(24 * time.Hour) // transitions are further apart
+		if _, nextOffset := next.Zone(); nextOffset != offset {
+			// binary search in seconds, the offset changes in (t, next]
+			for next.Sub(t) > time.Second {
+				mid := t.Add(next.Sub(t) / 2).Truncate(time.Second)
+				if _, midOffset := mid.Zone(); midOffset == offset {
+					t = mid
+				} else {
+					next = mid
+				}
+			}
+			return next
+		}
+		t = next
+	}
+	return time.Time{}
+}
+
+// observance returns a STANDARD or DAYLIGHT component for the offset at time at. Its DTSTART is the local time in the offset before.
+func observance(before, at time.Time) *ical.Component {
+	name := ical.CompTimezoneStandard
+	if at.IsDST() {
+		name = ical.CompTimezoneDaylight
+	}
+	_, offsetFrom := before.Zone()
+	tzname, offsetTo := at.Zone()
+
+	comp := ical.NewComponent(name)
+	dtstart := ical.NewProp(ical.PropDateTimeStart)
+	dtstart.Value = at.In(time.FixedZone("", offsetFrom)).Format("20060102T150405") // local time without TZID
+	comp.Props.Set(dtstart)
+	for prop, offset := range map[string]int{ical.PropTimezoneOffsetFrom: offsetFrom, ical.PropTimezoneOffsetTo: offsetTo} {
+		utcOffset := ical.NewProp(prop) // SetText would add VALUE=TEXT, but the value type is UTC-OFFSET
+		utcOffset.Value = formatOffset(offset)
+		comp.Props.Set(utcOffset)
+	}
+	comp.Props.SetText(ical.PropTimezoneName, tzname)
+	return comp
+}
+
+// formatOffset formats a UTC offset like "+0100" or "-0930".
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+	if seconds%60 != 0 {
+		s += fmt.Sprintf("%02d", seconds%60)
+	}
+	return s
+}
@@ -0,0 +1,51 @@
+package shiftpad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+func TestVTimezone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	tz := VTimezone(berlin, time.Date(2025, time.January, 1, 0, 0, 0, 0, berlin), time.Date(2026, time.January, 1, 0, 0, 0, 0, berlin))
+	if got := tz.Props.Get(ical.PropTimezoneID).Value; got != "Europe/Berlin" {
+		t.Fatalf("got TZID %q", got)
+	}
+
+	want := []struct {
+		name, dtstart, from, to, tzname string
+	}{
+		{ical.CompTimezoneStandard, "20250101T000000", "+0100", "+0100", "CET"},
+		{ical.CompTimezoneDaylight, "20250330T020000", "+0100", "+0200", "CEST"},
+		{ical.CompTimezoneStandard, "20251026T030000", "+0200", "+0100", "CET"},
+	}
+	if len(tz.Children) != len(want) {
+		t.Fatalf("got %d observances, want %d", len(tz.Children), len(want))
+	}
+	for i, w := range want {
+		comp := tz.Children[i]
+		got := []string{
+			comp.Name,
+			comp.Props.Get(ical.PropDateTimeStart).Value,
+			comp.Props.Get(ical.PropTimezoneOffsetFrom).Value,
+			comp.Props.Get(ical.PropTimezoneOffsetTo).Value,
+			comp.Props.Get(ical.PropTimezoneName).Value,
+		}
+		if got[0] != w.name || got[1] != w.dtstart || got[2] != w.from || got[3] != w.to || got[4] != w.tzname {
+			t.Fatalf("observance %d: got %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestFormatOffset(t *testing.T) {
+	for seconds, want := range map[int]string{0: "+0000", 3600: "+0100", -34200: "-0930", 3600 + 45*60 + 30: "+014530"} {
+		if got := formatOffset(seconds); got != want {
+			t.Fatalf("%d: got %q, want %q", seconds, got, want)
+		}
+	}
+}